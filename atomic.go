@@ -0,0 +1,94 @@
+package optionalv2
+
+import "sync/atomic"
+
+// AtomicOption is an Option that can be read and updated by multiple goroutines without a lock.
+// The zero value is None and ready to use. An AtomicOption must not be copied after first use.
+//
+// Options stored in an AtomicOption are copied, and the Options it returns are shared snapshots that must not be
+// modified in place.
+type AtomicOption[T any] struct {
+	_ noCopy
+	// p points to the current Option, or is nil for None
+	p atomic.Pointer[Option[T]]
+}
+
+// noCopy makes `go vet` report copies of the structs that embed it.
+type noCopy struct{}
+
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
+
+// NewAtomicOption returns an AtomicOption holding o.
+func NewAtomicOption[T any](o Option[T]) *AtomicOption[T] {
+	a := &AtomicOption[T]{}
+	a.Store(o)
+	return a
+}
+
+// Load returns the current Option.
+func (a *AtomicOption[T]) Load() Option[T] {
+	return a.unbox(a.p.Load())
+}
+
+// Store sets the current Option to o.
+func (a *AtomicOption[T]) Store(o Option[T]) {
+	a.p.Store(a.box(o))
+}
+
+// Swap sets the current Option to o and returns the previous one.
+func (a *AtomicOption[T]) Swap(o Option[T]) Option[T] {
+	return a.unbox(a.p.Swap(a.box(o)))
+}
+
+// Take returns the current Option and resets it to None.
+func (a *AtomicOption[T]) Take() Option[T] {
+	return a.unbox(a.p.Swap(nil))
+}
+
+// StoreIfNone sets the current Option to o only if it is None, and returns whether it did.
+// Note that a null Option isn't None, so it isn't replaced.
+func (a *AtomicOption[T]) StoreIfNone(o Option[T]) bool {
+	boxed := a.box(o)
+	if boxed == nil {
+		// storing None over None is a no-op
+		return a.p.Load() == nil
+	}
+	return a.p.CompareAndSwap(nil, boxed)
+}
+
+// CompareAndSwap sets the current Option of a to new only if it is Equal to old, and returns whether it did.
+func CompareAndSwap[T comparable](a *AtomicOption[T], old, new Option[T]) bool {
+	boxed := a.box(new)
+	for {
+		current := a.p.Load()
+		if !Equal(a.unbox(current), old) {
+			return false
+		}
+		if a.p.CompareAndSwap(current, boxed) {
+			return true
+		}
+		// the Option was updated concurrently, so compare again
+	}
+}
+
+// box returns a private copy of o, or nil for None.
+func (a *AtomicOption[T]) box(o Option[T]) *Option[T] {
+	var boxed Option[T]
+	switch o.State() {
+	case StateNone:
+		return nil
+	case StateNull:
+		boxed = null[T]()
+	default:
+		boxed = Option[T]{true: o[true]}
+	}
+	return &boxed
+}
+
+func (a *AtomicOption[T]) unbox(p *Option[T]) Option[T] {
+	if p == nil {
+		return None[T]()
+	}
+	return *p
+}
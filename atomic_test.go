@@ -0,0 +1,228 @@
+package optionalv2_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+func TestAtomicOption(t *testing.T) {
+	// Test the zero value is None
+	t.Run("ZeroValue", func(t *testing.T) {
+		var a optionalv2.AtomicOption[string]
+		assert.True(t, a.Load().IsNone())
+		assert.True(t, a.Take().IsNone())
+	})
+
+	// Test Load, Store and Swap for the three states
+	t.Run("LoadStoreSwap", func(t *testing.T) {
+		a := optionalv2.NewAtomicOption(optionalv2.Some("leader-1"))
+		assert.Equal(t, optionalv2.Some("leader-1"), a.Load())
+
+		a.Store(optionalv2.Some(""))
+		assert.Equal(t, optionalv2.StateNull, a.Load().State())
+
+		old := a.Swap(optionalv2.Some("leader-2"))
+		assert.Equal(t, optionalv2.StateNull, old.State())
+		assert.Equal(t, "leader-2", a.Load().Unwrap())
+
+		old = a.Swap(optionalv2.None[string]())
+		assert.Equal(t, "leader-2", old.Unwrap())
+		assert.True(t, a.Load().IsNone())
+	})
+
+	// Test Store copies the Option
+	t.Run("StoreCopies", func(t *testing.T) {
+		o := optionalv2.Some(1)
+		a := optionalv2.NewAtomicOption(o)
+		o[true] = 2
+		assert.Equal(t, 1, a.Load().Unwrap())
+	})
+
+	// Test Take resets to None
+	t.Run("Take", func(t *testing.T) {
+		a := optionalv2.NewAtomicOption(optionalv2.Some(42))
+		assert.Equal(t, optionalv2.Some(42), a.Take())
+		assert.True(t, a.Load().IsNone())
+	})
+
+	// Test StoreIfNone only replaces None
+	t.Run("StoreIfNone", func(t *testing.T) {
+		var a optionalv2.AtomicOption[int]
+		assert.True(t, a.StoreIfNone(optionalv2.None[int]()))
+		assert.True(t, a.StoreIfNone(optionalv2.Some(0)))
+		assert.False(t, a.StoreIfNone(optionalv2.Some(1)))
+		assert.Equal(t, optionalv2.StateNull, a.Load().State())
+		assert.False(t, a.StoreIfNone(optionalv2.None[int]()))
+	})
+
+	// Test CompareAndSwap compares states and values
+	t.Run("CompareAndSwap", func(t *testing.T) {
+		var a optionalv2.AtomicOption[int]
+		assert.False(t, optionalv2.CompareAndSwap(&a, optionalv2.Some(0), optionalv2.Some(1)))
+		assert.True(t, optionalv2.CompareAndSwap(&a, optionalv2.None[int](), optionalv2.Some(0)))
+		assert.False(t, optionalv2.CompareAndSwap(&a, optionalv2.None[int](), optionalv2.Some(1)))
+		assert.True(t, optionalv2.CompareAndSwap(&a, optionalv2.Some(0), optionalv2.Some(1)))
+		assert.False(t, optionalv2.CompareAndSwap(&a, optionalv2.Some(2), optionalv2.Some(3)))
+		assert.True(t, optionalv2.CompareAndSwap(&a, optionalv2.Some(1), optionalv2.None[int]()))
+		assert.True(t, a.Load().IsNone())
+	})
+}
+
+func TestAtomicOptionConcurrent(t *testing.T) {
+	const goroutines = 16
+	const iterations = 1000
+
+	// Test concurrent CompareAndSwap loops don't lose updates
+	t.Run("CompareAndSwap", func(t *testing.T) {
+		a := optionalv2.NewAtomicOption(optionalv2.Some(1))
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < iterations; i++ {
+					for {
+						old := a.Load()
+						if optionalv2.CompareAndSwap(a, old, optionalv2.Some(old.Unwrap()+1)) {
+							break
+						}
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1+goroutines*iterations, a.Load().Unwrap())
+	})
+
+	// Test exactly one goroutine wins StoreIfNone, and exactly one Take observes each stored value
+	t.Run("StoreIfNoneAndTake", func(t *testing.T) {
+		var a optionalv2.AtomicOption[int]
+		var stored, taken atomic.Int64
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < iterations; i++ {
+					if a.StoreIfNone(optionalv2.Some(g + 1)) {
+						stored.Add(1)
+					}
+					if a.Take().IsSome() {
+						taken.Add(1)
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+		if a.Take().IsSome() {
+			taken.Add(1)
+		}
+		assert.Positive(t, stored.Load())
+		assert.Equal(t, stored.Load(), taken.Load())
+	})
+
+	// Test readers always observe a complete Option while writers swap it
+	t.Run("LoadDuringStore", func(t *testing.T) {
+		a := optionalv2.NewAtomicOption(optionalv2.Some("a"))
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < iterations; i++ {
+					a.Store(optionalv2.Some("b"))
+					a.Store(optionalv2.Some(""))
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for i := 0; i < iterations; i++ {
+					o := a.Load()
+					assert.Contains(t, []string{"a", "b", ""}, o.Unwrap())
+					assert.True(t, o.IsSome())
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+// mutexOption is the hand-written alternative to AtomicOption the benchmarks compare against.
+type mutexOption[T any] struct {
+	mu sync.RWMutex
+	o  optionalv2.Option[T]
+}
+
+func (m *mutexOption[T]) Load() optionalv2.Option[T] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.o
+}
+
+func (m *mutexOption[T]) Store(o optionalv2.Option[T]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.o = o
+}
+
+func BenchmarkAtomicOption(b *testing.B) {
+	value := optionalv2.Some("token")
+
+	b.Run("Load", func(b *testing.B) {
+		a := optionalv2.NewAtomicOption(value)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = a.Load()
+			}
+		})
+	})
+
+	b.Run("LoadMutex", func(b *testing.B) {
+		m := &mutexOption[string]{o: value}
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = m.Load()
+			}
+		})
+	})
+
+	b.Run("MixedLoadStore", func(b *testing.B) {
+		a := optionalv2.NewAtomicOption(value)
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				if i%8 == 0 {
+					a.Store(value)
+				} else {
+					_ = a.Load()
+				}
+			}
+		})
+	})
+
+	b.Run("MixedLoadStoreMutex", func(b *testing.B) {
+		m := &mutexOption[string]{o: value}
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				if i%8 == 0 {
+					m.Store(value)
+				} else {
+					_ = m.Load()
+				}
+			}
+		})
+	})
+
+	b.Run("CompareAndSwap", func(b *testing.B) {
+		a := optionalv2.NewAtomicOption(optionalv2.Some(1))
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				old := a.Load()
+				optionalv2.CompareAndSwap(a, old, optionalv2.Some(old.Unwrap()+1))
+			}
+		})
+	})
+}
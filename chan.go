@@ -0,0 +1,101 @@
+package optionalv2
+
+import "context"
+
+// Recv receives a value from ch, blocking until one is available.
+// It returns None if ch is closed. Like Some, a received zero value becomes null.
+func Recv[T any](ch <-chan T) Option[T] {
+	v, ok := <-ch
+	if !ok {
+		return None[T]()
+	}
+	return Some(v)
+}
+
+// TryRecv is like Recv, but doesn't block: it returns None if no value is ready.
+func TryRecv[T any](ch <-chan T) Option[T] {
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			return None[T]()
+		}
+		return Some(v)
+	default:
+		return None[T]()
+	}
+}
+
+// RecvContext is like Recv, but returns None if ctx is done before a value is received.
+func RecvContext[T any](ctx context.Context, ch <-chan T) Option[T] {
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			return None[T]()
+		}
+		return Some(v)
+	case <-ctx.Done():
+		return None[T]()
+	}
+}
+
+// Future is the Option result of a function running in the background.
+type Future[T any] struct {
+	done  chan struct{}
+	value Option[T]
+}
+
+// Async calls f with ctx in a new goroutine and returns a Future for its result.
+// f should return when ctx is done, so that the goroutine doesn't outlive the work it was started for.
+func Async[T any](ctx context.Context, f func(ctx context.Context) Option[T]) *Future[T] {
+	future := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer close(future.done)
+		future.value = f(ctx)
+	}()
+	return future
+}
+
+// Await waits for the result of the Future. It returns None if ctx is done first.
+// Await can be called any number of times, from any goroutine.
+func (f *Future[T]) Await(ctx context.Context) Option[T] {
+	select {
+	case <-f.done:
+		return f.value
+	case <-ctx.Done():
+		return None[T]()
+	}
+}
+
+// Done returns a channel that is closed when the result of the Future is ready.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// FirstSome calls funcs concurrently and returns the first result that is Some (or null, like IsSome).
+// Once a result is found, the context passed to the other functions is canceled; FirstSome doesn't wait for them
+// to return, but they never block on delivering their result.
+// It returns None if every function returns None, or if ctx is done first.
+func FirstSome[T any](ctx context.Context, funcs ...func(ctx context.Context) Option[T]) Option[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// buffered, so that the functions that finish after FirstSome returned don't block
+	results := make(chan Option[T], len(funcs))
+	for _, f := range funcs {
+		go func(f func(ctx context.Context) Option[T]) {
+			results <- f(ctx)
+		}(f)
+	}
+
+	for range funcs {
+		select {
+		case result := <-results:
+			if result.IsSome() {
+				return result
+			}
+		case <-ctx.Done():
+			return None[T]()
+		}
+	}
+	return None[T]()
+}
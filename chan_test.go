@@ -0,0 +1,151 @@
+package optionalv2_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+// assertNoGoroutineLeak fails the test if it ends with more goroutines than it started with.
+func assertNoGoroutineLeak(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		// goroutines may still be returning, so poll for a while
+		after := runtime.NumGoroutine()
+		for deadline := time.Now().Add(time.Second); after > before && time.Now().Before(deadline); {
+			time.Sleep(10 * time.Millisecond)
+			after = runtime.NumGoroutine()
+		}
+		assert.LessOrEqual(t, after, before, "leaked %d goroutine(s)", after-before)
+	})
+}
+
+func TestRecv(t *testing.T) {
+	// Test Recv of values and of a closed channel
+	t.Run("Recv", func(t *testing.T) {
+		ch := make(chan int, 2)
+		ch <- 1
+		ch <- 0
+		close(ch)
+		assert.Equal(t, optionalv2.Some(1), optionalv2.Recv(ch))
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Recv(ch).State())
+		assert.True(t, optionalv2.Recv(ch).IsNone())
+	})
+
+	// Test TryRecv doesn't block
+	t.Run("TryRecv", func(t *testing.T) {
+		ch := make(chan string, 1)
+		assert.True(t, optionalv2.TryRecv(ch).IsNone())
+		ch <- "a"
+		assert.Equal(t, optionalv2.Some("a"), optionalv2.TryRecv(ch))
+		close(ch)
+		assert.True(t, optionalv2.TryRecv(ch).IsNone())
+	})
+
+	// Test RecvContext returns None when the context is done
+	t.Run("RecvContext", func(t *testing.T) {
+		assertNoGoroutineLeak(t)
+		ch := make(chan int)
+		go func() { ch <- 42 }()
+		assert.Equal(t, optionalv2.Some(42), optionalv2.RecvContext(context.Background(), ch))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.True(t, optionalv2.RecvContext(ctx, ch).IsNone())
+
+		close(ch)
+		assert.True(t, optionalv2.RecvContext(context.Background(), ch).IsNone())
+	})
+}
+
+func TestFuture(t *testing.T) {
+	// Test Await returns the result, any number of times
+	t.Run("Await", func(t *testing.T) {
+		assertNoGoroutineLeak(t)
+		future := optionalv2.Async(context.Background(), func(ctx context.Context) optionalv2.Option[string] {
+			return optionalv2.Some("done")
+		})
+		assert.Equal(t, optionalv2.Some("done"), future.Await(context.Background()))
+		<-future.Done()
+		assert.Equal(t, optionalv2.Some("done"), future.Await(context.Background()))
+	})
+
+	// Test Await returns None on timeout, and the function stops with its context
+	t.Run("Timeout", func(t *testing.T) {
+		assertNoGoroutineLeak(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		future := optionalv2.Async(ctx, func(ctx context.Context) optionalv2.Option[int] {
+			<-ctx.Done()
+			return optionalv2.None[int]()
+		})
+
+		awaitCtx, awaitCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer awaitCancel()
+		assert.True(t, future.Await(awaitCtx).IsNone())
+
+		cancel()
+		<-future.Done()
+	})
+}
+
+func TestFirstSome(t *testing.T) {
+	slow := func(v int) func(ctx context.Context) optionalv2.Option[int] {
+		return func(ctx context.Context) optionalv2.Option[int] {
+			select {
+			case <-time.After(time.Second):
+				return optionalv2.Some(v)
+			case <-ctx.Done():
+				return optionalv2.None[int]()
+			}
+		}
+	}
+	fast := func(o optionalv2.Option[int]) func(ctx context.Context) optionalv2.Option[int] {
+		return func(ctx context.Context) optionalv2.Option[int] {
+			return o
+		}
+	}
+
+	// Test the first Some wins and the other functions are canceled
+	t.Run("FirstWins", func(t *testing.T) {
+		assertNoGoroutineLeak(t)
+		result := optionalv2.FirstSome(context.Background(), slow(1), fast(optionalv2.None[int]()), fast(optionalv2.Some(2)), slow(3))
+		assert.Equal(t, optionalv2.Some(2), result)
+	})
+
+	// Test null counts as a result
+	t.Run("Null", func(t *testing.T) {
+		assertNoGoroutineLeak(t)
+		result := optionalv2.FirstSome(context.Background(), slow(1), fast(optionalv2.Some(0)))
+		assert.Equal(t, optionalv2.StateNull, result.State())
+	})
+
+	// Test None when every function returns None, or without functions
+	t.Run("AllNone", func(t *testing.T) {
+		assertNoGoroutineLeak(t)
+		assert.True(t, optionalv2.FirstSome(context.Background(), fast(optionalv2.None[int]()), fast(optionalv2.None[int]())).IsNone())
+		assert.True(t, optionalv2.FirstSome[int](context.Background()).IsNone())
+	})
+
+	// Test None when the context is done first
+	t.Run("Canceled", func(t *testing.T) {
+		assertNoGoroutineLeak(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.True(t, optionalv2.FirstSome(ctx, slow(1), slow(2)).IsNone())
+	})
+
+	// Test functions that ignore cancellation don't block after FirstSome returned
+	t.Run("IgnoresCancellation", func(t *testing.T) {
+		assertNoGoroutineLeak(t)
+		stubborn := func(ctx context.Context) optionalv2.Option[int] {
+			time.Sleep(20 * time.Millisecond)
+			return optionalv2.Some(9)
+		}
+		assert.Equal(t, optionalv2.Some(1), optionalv2.FirstSome(context.Background(), stubborn, fast(optionalv2.Some(1))))
+	})
+}
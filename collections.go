@@ -0,0 +1,78 @@
+package optionalv2
+
+// Get returns the value stored in m for k, or None if m doesn't contain k.
+// Like Some, a stored zero value becomes null.
+func Get[M ~map[K]V, K comparable, V any](m M, k K) Option[V] {
+	v, ok := m[k]
+	if !ok {
+		return None[V]()
+	}
+	return Some(v)
+}
+
+// At returns the element of s at index i, or None if i is out of range.
+// Like Some, a zero element becomes null.
+func At[S ~[]E, E any](s S, i int) Option[E] {
+	if i < 0 || i >= len(s) {
+		return None[E]()
+	}
+	return Some(s[i])
+}
+
+// FirstWhere returns the first element of s that satisfies predicate, or None if there isn't any.
+func FirstWhere[S ~[]E, E any](s S, predicate func(v E) bool) Option[E] {
+	for _, v := range s {
+		if predicate(v) {
+			return Some(v)
+		}
+	}
+	return None[E]()
+}
+
+// LastWhere returns the last element of s that satisfies predicate, or None if there isn't any.
+func LastWhere[S ~[]E, E any](s S, predicate func(v E) bool) Option[E] {
+	for i := len(s) - 1; i >= 0; i-- {
+		if predicate(s[i]) {
+			return Some(s[i])
+		}
+	}
+	return None[E]()
+}
+
+// Pop removes the last element of the slice pointed to by s and returns it, or returns None if the slice is empty.
+func Pop[S ~[]E, E any](s *S) Option[E] {
+	n := len(*s)
+	if n == 0 {
+		return None[E]()
+	}
+	v := (*s)[n-1]
+	// clear the element so that the backing array doesn't keep it alive
+	var zero E
+	(*s)[n-1] = zero
+	*s = (*s)[:n-1]
+	return Some(v)
+}
+
+// MapValuesSome returns the values of the Options of m that aren't None, keyed like in m.
+// A null Option contributes the zero value, like Unwrap.
+func MapValuesSome[M ~map[K]Option[V], K comparable, V any](m M) map[K]V {
+	result := make(map[K]V, len(m))
+	for k, o := range m {
+		if o.IsSome() {
+			result[k] = o.Unwrap()
+		}
+	}
+	return result
+}
+
+// CompactSome returns the values of the Options of s that aren't None, in order.
+// A null Option contributes the zero value, like Unwrap.
+func CompactSome[S ~[]Option[T], T any](s S) []T {
+	result := make([]T, 0, len(s))
+	for _, o := range s {
+		if o.IsSome() {
+			result = append(result, o.Unwrap())
+		}
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+package optionalv2_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+func TestCollections(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	// Test Get for present, zero and missing keys
+	t.Run("Get", func(t *testing.T) {
+		m := map[string]int{"a": 1, "zero": 0}
+		assert.Equal(t, optionalv2.Some(1), optionalv2.Get(m, "a"))
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Get(m, "zero").State())
+		assert.True(t, optionalv2.Get(m, "missing").IsNone())
+		assert.True(t, optionalv2.Get(map[string]int(nil), "a").IsNone())
+	})
+
+	// Test At for in-range and out-of-range indexes
+	t.Run("At", func(t *testing.T) {
+		s := []string{"a", ""}
+		assert.Equal(t, optionalv2.Some("a"), optionalv2.At(s, 0))
+		assert.Equal(t, optionalv2.StateNull, optionalv2.At(s, 1).State())
+		assert.True(t, optionalv2.At(s, 2).IsNone())
+		assert.True(t, optionalv2.At(s, -1).IsNone())
+	})
+
+	// Test FirstWhere and LastWhere
+	t.Run("FirstWhereLastWhere", func(t *testing.T) {
+		s := []int{1, 2, 3, 4, 5}
+		assert.Equal(t, optionalv2.Some(2), optionalv2.FirstWhere(s, isEven))
+		assert.Equal(t, optionalv2.Some(4), optionalv2.LastWhere(s, isEven))
+		assert.True(t, optionalv2.FirstWhere([]int{1, 3}, isEven).IsNone())
+		assert.True(t, optionalv2.LastWhere([]int(nil), isEven).IsNone())
+	})
+
+	// Test Pop removes the last element
+	t.Run("Pop", func(t *testing.T) {
+		s := []int{1, 2}
+		assert.Equal(t, optionalv2.Some(2), optionalv2.Pop(&s))
+		assert.Equal(t, []int{1}, s)
+		assert.Equal(t, optionalv2.Some(1), optionalv2.Pop(&s))
+		assert.Empty(t, s)
+		assert.True(t, optionalv2.Pop(&s).IsNone())
+	})
+
+	// Test MapValuesSome drops None values
+	t.Run("MapValuesSome", func(t *testing.T) {
+		m := map[string]optionalv2.Option[int]{
+			"a":    optionalv2.Some(1),
+			"null": optionalv2.Some(0),
+			"none": optionalv2.None[int](),
+		}
+		assert.Equal(t, map[string]int{"a": 1, "null": 0}, optionalv2.MapValuesSome(m))
+	})
+
+	// Test CompactSome drops None values and keeps the order
+	t.Run("CompactSome", func(t *testing.T) {
+		s := []optionalv2.Option[string]{
+			optionalv2.Some("a"),
+			optionalv2.None[string](),
+			optionalv2.Some(""),
+			optionalv2.Some("b"),
+		}
+		assert.Equal(t, []string{"a", "", "b"}, optionalv2.CompactSome(s))
+		assert.Empty(t, optionalv2.CompactSome([]optionalv2.Option[string]{}))
+	})
+}
+
+var benchmarkSink optionalv2.Option[int]
+
+func BenchmarkCollections(b *testing.B) {
+	m := map[string]int{"a": 1, "b": 2}
+	s := make([]int, 100)
+	for i := range s {
+		s[i] = i + 1
+	}
+	isTarget := func(v int) bool { return v == 50 }
+
+	b.Run("Get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchmarkSink = optionalv2.Get(m, "a")
+		}
+	})
+
+	b.Run("GetHandWritten", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if v, ok := m["a"]; ok {
+				benchmarkSink = optionalv2.Some(v)
+			} else {
+				benchmarkSink = optionalv2.None[int]()
+			}
+		}
+	})
+
+	b.Run("At", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchmarkSink = optionalv2.At(s, 10)
+		}
+	})
+
+	b.Run("AtHandWritten", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if idx := 10; idx >= 0 && idx < len(s) {
+				benchmarkSink = optionalv2.Some(s[idx])
+			} else {
+				benchmarkSink = optionalv2.None[int]()
+			}
+		}
+	})
+
+	b.Run("FirstWhere", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchmarkSink = optionalv2.FirstWhere(s, isTarget)
+		}
+	})
+
+	b.Run("FirstWhereHandWritten", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchmarkSink = optionalv2.None[int]()
+			for _, v := range s {
+				if isTarget(v) {
+					benchmarkSink = optionalv2.Some(v)
+					break
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package optionalv2
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// Equal returns whether two Options are in the same state and, if they are Some, hold equal values.
+func Equal[T comparable](a, b Option[T]) bool {
+	return EqualFunc(a, b, func(x, y T) bool {
+		return x == y
+	})
+}
+
+// EqualFunc is like Equal, but uses eq to compare the values of two Some Options.
+func EqualFunc[T any](a, b Option[T], eq func(x, y T) bool) bool {
+	stateA, stateB := a.State(), b.State()
+	if stateA != stateB {
+		return false
+	}
+	if stateA != StateSome {
+		return true
+	}
+	return eq(a[true], b[true])
+}
+
+// Compare compares two Options, ordering None before null and null before Some.
+// Two Some Options are ordered by their values, like cmp.Compare.
+// The result is -1 if a is less than b, 0 if they are equal, and +1 if a is greater than b.
+func Compare[T cmp.Ordered](a, b Option[T]) int {
+	return CompareFunc(a, b, cmp.Compare[T])
+}
+
+// CompareFunc is like Compare, but uses compare to order the values of two Some Options.
+func CompareFunc[T any](a, b Option[T], compare func(x, y T) int) int {
+	stateA, stateB := a.State(), b.State()
+	if stateA != stateB {
+		return cmp.Compare(stateA, stateB)
+	}
+	if stateA != StateSome {
+		return 0
+	}
+	return compare(a[true], b[true])
+}
+
+// Hash writes the state and the value of the Option to h, so that Options that are Equal produce the same hash.
+//
+//	var h maphash.Hash
+//	h.SetSeed(seed)
+//	opt.Hash(&h)
+//	sum := h.Sum64()
+//
+// Like the == operator, it panics if the value contains a slice, a map or a function.
+func (o Option[T]) Hash(h *maphash.Hash) {
+	state := o.State()
+	_ = h.WriteByte(byte(state))
+	if state == StateSome {
+		v := o[true]
+		hashValue(h, reflect.ValueOf(&v).Elem())
+	}
+}
+
+// hashValue writes v to h, following the semantics of the == operator.
+func hashValue(h *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	writeUint := func(n uint64) {
+		binary.LittleEndian.PutUint64(buf[:], n)
+		_, _ = h.Write(buf[:])
+	}
+	writeFloat := func(f float64) {
+		// +0 and -0 are equal
+		if f == 0 {
+			f = 0
+		}
+		writeUint(math.Float64bits(f))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			writeUint(1)
+		} else {
+			writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(real(v.Complex()))
+		writeFloat(imag(v.Complex()))
+	case reflect.String:
+		writeUint(uint64(v.Len()))
+		_, _ = h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			writeUint(0)
+			return
+		}
+		_, _ = h.WriteString(v.Elem().Type().String())
+		hashValue(h, v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			hashValue(h, v.Field(i))
+		}
+	default:
+		panic(fmt.Sprintf("optionalv2: Hash of unhashable type %s", v.Type()))
+	}
+}
@@ -0,0 +1,94 @@
+package optionalv2_test
+
+import (
+	"hash/maphash"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+func hashOf[T any](seed maphash.Seed, o optionalv2.Option[T]) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	o.Hash(&h)
+	return h.Sum64()
+}
+
+func TestCompare(t *testing.T) {
+	// Test Equal and EqualFunc
+	t.Run("Equal", func(t *testing.T) {
+		assert.True(t, optionalv2.Equal(optionalv2.Some(1), optionalv2.Some(1)))
+		assert.False(t, optionalv2.Equal(optionalv2.Some(1), optionalv2.Some(2)))
+		assert.True(t, optionalv2.Equal(optionalv2.None[int](), optionalv2.Option[int](nil)))
+		assert.True(t, optionalv2.Equal(optionalv2.Some(0), optionalv2.Some(0)))
+		assert.False(t, optionalv2.Equal(optionalv2.Some(0), optionalv2.None[int]()))
+		assert.False(t, optionalv2.Equal(optionalv2.Some(1), optionalv2.Some(0)))
+
+		eq := func(x, y []int) bool { return slices.Equal(x, y) }
+		assert.True(t, optionalv2.EqualFunc(optionalv2.Some([]int{1, 2}), optionalv2.Some([]int{1, 2}), eq))
+		assert.False(t, optionalv2.EqualFunc(optionalv2.Some([]int{1}), optionalv2.Some([]int{2}), eq))
+		assert.True(t, optionalv2.EqualFunc(optionalv2.None[[]int](), optionalv2.None[[]int](), eq))
+	})
+
+	// Test Compare orders None < null < Some
+	t.Run("Compare", func(t *testing.T) {
+		none, null := optionalv2.None[int](), optionalv2.Some(0)
+		assert.Equal(t, -1, optionalv2.Compare(none, null))
+		assert.Equal(t, -1, optionalv2.Compare(null, optionalv2.Some(-5)))
+		assert.Equal(t, 1, optionalv2.Compare(optionalv2.Some(-5), none))
+		assert.Equal(t, 0, optionalv2.Compare(none, optionalv2.None[int]()))
+		assert.Equal(t, 0, optionalv2.Compare(null, optionalv2.Some(0)))
+		assert.Equal(t, -1, optionalv2.Compare(optionalv2.Some(1), optionalv2.Some(2)))
+		assert.Equal(t, 1, optionalv2.Compare(optionalv2.Some("b"), optionalv2.Some("a")))
+
+		assert.Equal(t, 1, optionalv2.CompareFunc(optionalv2.Some("B"), optionalv2.Some("a"), func(x, y string) int {
+			return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+		}))
+	})
+
+	// Test Hash agrees with Equal
+	t.Run("Hash", func(t *testing.T) {
+		seed := maphash.MakeSeed()
+		assert.Equal(t, hashOf(seed, optionalv2.Some(42)), hashOf(seed, optionalv2.Some(42)))
+		assert.NotEqual(t, hashOf(seed, optionalv2.Some(42)), hashOf(seed, optionalv2.Some(43)))
+		assert.Equal(t, hashOf(seed, optionalv2.None[int]()), hashOf(seed, optionalv2.Option[int](nil)))
+		assert.NotEqual(t, hashOf(seed, optionalv2.None[int]()), hashOf(seed, optionalv2.Some(0)))
+		assert.Equal(t, hashOf(seed, optionalv2.Some(math.Copysign(0, -1))), hashOf(seed, optionalv2.Some(0.0)))
+
+		type key struct {
+			Name string
+			At   time.Time
+			Tags [2]string
+		}
+		at := time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)
+		a := optionalv2.Some(key{Name: "a", At: at, Tags: [2]string{"x", "y"}})
+		b := optionalv2.Some(key{Name: "a", At: at, Tags: [2]string{"x", "y"}})
+		c := optionalv2.Some(key{Name: "a", At: at, Tags: [2]string{"xy", ""}})
+		assert.True(t, optionalv2.Equal(a, b))
+		assert.Equal(t, hashOf(seed, a), hashOf(seed, b))
+		assert.NotEqual(t, hashOf(seed, a), hashOf(seed, c))
+
+		var i any = "value"
+		assert.Equal(t, hashOf(seed, optionalv2.Some(i)), hashOf(seed, optionalv2.Some[any]("value")))
+
+		assert.Panics(t, func() { hashOf(seed, optionalv2.Some([]int{1})) })
+	})
+
+	// Test Hash can be used to build a map keyed by Options
+	t.Run("HashAsMapKey", func(t *testing.T) {
+		seed := maphash.MakeSeed()
+		counts := map[uint64]int{}
+		for _, o := range []optionalv2.Option[string]{
+			optionalv2.Some("a"), optionalv2.None[string](), optionalv2.Some("a"), optionalv2.Some(""),
+		} {
+			counts[hashOf(seed, o)]++
+		}
+		assert.Len(t, counts, 3)
+		assert.Equal(t, 2, counts[hashOf(seed, optionalv2.Some("a"))])
+	})
+}
@@ -0,0 +1,48 @@
+package optionalv2
+
+import "context"
+
+// Key is a typed context key for values of type T.
+// Keys are compared by identity, so two keys created with the same name are still distinct.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey returns a new context key for values of type T. The name is only used for debugging.
+//
+//	var TenantKey = optionalv2.NewKey[string]("tenant")
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// String returns the name of the key.
+func (k *Key[T]) String() string {
+	return k.name
+}
+
+// From returns the value stored in ctx for the key.
+// It returns None if no value was stored, and null if WithNull was used to mask a parent value.
+func (k *Key[T]) From(ctx context.Context) Option[T] {
+	o, ok := ctx.Value(k).(Option[T])
+	if !ok {
+		return None[T]()
+	}
+	return o
+}
+
+// WithValue returns a copy of ctx in which key holds Some(v).
+// Like Some, a zero value is stored as null.
+func WithValue[T any](ctx context.Context, key *Key[T], v T) context.Context {
+	return context.WithValue(ctx, key, Some(v))
+}
+
+// WithNull returns a copy of ctx in which key holds an explicit null, masking any value stored by a parent context.
+func WithNull[T any](ctx context.Context, key *Key[T]) context.Context {
+	return context.WithValue(ctx, key, null[T]())
+}
+
+// WithOption returns a copy of ctx in which key holds o. A None o masks any value stored by a parent context, so
+// that the key reads as if it had never been set.
+func WithOption[T any](ctx context.Context, key *Key[T], o Option[T]) context.Context {
+	return context.WithValue(ctx, key, o)
+}
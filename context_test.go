@@ -0,0 +1,58 @@
+package optionalv2_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+var (
+	tenantKey = optionalv2.NewKey[string]("tenant")
+	userKey   = optionalv2.NewKey[int]("user")
+)
+
+func TestContext(t *testing.T) {
+	// Test an unset key is None
+	t.Run("Unset", func(t *testing.T) {
+		assert.True(t, tenantKey.From(context.Background()).IsNone())
+	})
+
+	// Test WithValue stores Some, and a zero value as null
+	t.Run("WithValue", func(t *testing.T) {
+		ctx := optionalv2.WithValue(context.Background(), tenantKey, "acme")
+		assert.Equal(t, optionalv2.Some("acme"), tenantKey.From(ctx))
+		assert.True(t, userKey.From(ctx).IsNone())
+
+		ctx = optionalv2.WithValue(ctx, userKey, 0)
+		assert.Equal(t, optionalv2.StateNull, userKey.From(ctx).State())
+	})
+
+	// Test WithNull masks a parent value without affecting the parent
+	t.Run("WithNull", func(t *testing.T) {
+		parent := optionalv2.WithValue(context.Background(), tenantKey, "acme")
+		child := optionalv2.WithNull(parent, tenantKey)
+		assert.Equal(t, optionalv2.StateNull, tenantKey.From(child).State())
+		assert.Equal(t, "acme", tenantKey.From(parent).Unwrap())
+
+		grandchild := optionalv2.WithValue(child, tenantKey, "globex")
+		assert.Equal(t, "globex", tenantKey.From(grandchild).Unwrap())
+	})
+
+	// Test WithOption stores any state, and None masks a parent value as unset
+	t.Run("WithOption", func(t *testing.T) {
+		parent := optionalv2.WithValue(context.Background(), tenantKey, "acme")
+		assert.True(t, tenantKey.From(optionalv2.WithOption(parent, tenantKey, optionalv2.None[string]())).IsNone())
+		assert.Equal(t, "globex", tenantKey.From(optionalv2.WithOption(parent, tenantKey, optionalv2.Some("globex"))).Unwrap())
+	})
+
+	// Test keys with the same name and type are distinct
+	t.Run("KeyIdentity", func(t *testing.T) {
+		other := optionalv2.NewKey[string]("tenant")
+		ctx := optionalv2.WithValue(context.Background(), tenantKey, "acme")
+		assert.True(t, other.From(ctx).IsNone())
+		assert.Equal(t, "tenant", fmt.Sprint(other))
+	})
+}
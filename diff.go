@@ -0,0 +1,208 @@
+package optionalv2
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrUnknownPatchField represents the error that is raised when Apply receives a Patch with a key that doesn't match
+// any field.
+var ErrUnknownPatchField = errors.New("unknown patch field")
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Patch describes the changes between two values of a struct type, keyed by the JSON names of the fields.
+// A field that is absent (None) is unchanged, a null field was cleared, and a Some field was changed to its value.
+// A changed nested struct field holds a Patch of its own fields.
+//
+// A Patch marshals to JSON like a struct of Option fields with `omitempty` (e.g. `{"name":"Bob","email":null}`), and
+// a Patch unmarshalled from JSON can be applied as well.
+type Patch map[string]Option[any]
+
+// Diff returns the Patch that turns old into new.
+//
+// A field is changed when its old and new values aren't reflect.DeepEqual, and it is cleared when its new value is
+// the zero value, or None or null for an Option field. Nested structs are compared field by field, except for the types
+// that marshal themselves (e.g. time.Time), which are compared as a whole like any other value.
+// Fields are named after their `json` tag, and embedded structs and pointers to structs are flattened.
+func Diff[T any](old, new T) (Patch, error) {
+	ov, nv := reflect.ValueOf(&old).Elem(), reflect.ValueOf(&new).Elem()
+	if ov.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+	return diffStruct(ov, nv), nil
+}
+
+// Apply applies the changes of patch to the struct pointed to by dst: it sets changed fields to their new value, and
+// resets cleared fields to their zero value (null for Option fields). Unchanged fields are left as is.
+// Values are converted to the type of their field, going through JSON if needed, so that an unmarshalled Patch can be
+// applied. All errors are reported together.
+func Apply[T any](dst *T, patch Patch) error {
+	v := reflect.ValueOf(dst).Elem()
+	if v.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	return applyStruct(v, patch, "")
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Patch.
+// Numbers are kept as json.Number, so that Apply converts them to integer fields without losing precision.
+func (p *Patch) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	patch := make(Patch, len(raw))
+	for name, value := range raw {
+		// if field was cleared
+		if bytes.Equal(value, NullBytes) {
+			patch[name] = null[any]()
+			continue
+		}
+		// otherwise, the field was changed, so decode its new value
+		decoder := json.NewDecoder(bytes.NewReader(value))
+		decoder.UseNumber()
+		var v any
+		if err := decoder.Decode(&v); err != nil {
+			return err
+		}
+		patch[name] = Option[any]{true: v}
+	}
+	*p = patch
+	return nil
+}
+
+func diffStruct(old, new reflect.Value) Patch {
+	patch := Patch{}
+	newFields := jsonFieldValues(new)
+	for name, of := range jsonFieldValues(old) {
+		nf := newFields[name]
+		t := of.Type()
+		switch {
+		case IsOptionType(t):
+			oldState, oldValue := ReflectGet(of)
+			newState, newValue := ReflectGet(nf)
+			if oldState == newState && reflect.DeepEqual(oldValue.Interface(), newValue.Interface()) {
+				continue
+			}
+			// store the new value as is: the state is already known, and Some of an `any` would ignore NullChecker
+			if newState != StateSome {
+				patch[name] = null[any]()
+			} else {
+				patch[name] = Option[any]{true: newValue.Interface()}
+			}
+		case isNestedStruct(t):
+			if nested := diffStruct(of, nf); len(nested) > 0 {
+				patch[name] = Option[any]{true: nested}
+			}
+		default:
+			if reflect.DeepEqual(of.Interface(), nf.Interface()) {
+				continue
+			}
+			if nf.IsZero() {
+				patch[name] = null[any]()
+			} else {
+				patch[name] = Option[any]{true: nf.Interface()}
+			}
+		}
+	}
+	return patch
+}
+
+func applyStruct(v reflect.Value, patch Patch, prefix string) error {
+	fields := map[string]jsonField{}
+	for _, f := range jsonFields(v.Type()) {
+		fields[f.Name] = f
+	}
+	var errs []error
+	for name, change := range patch {
+		f, ok := fields[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownPatchField, prefix+name))
+			continue
+		}
+		field, err := f.Alloc(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("apply %q: %w", prefix+name, err))
+			continue
+		}
+		if err := applyField(field, change, prefix+name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func applyField(field reflect.Value, change Option[any], path string) error {
+	t := field.Type()
+	// if field was cleared
+	if change.State() != StateSome {
+		if IsOptionType(t) {
+			ReflectSet(field, StateNull, reflect.Value{})
+		} else {
+			field.Set(reflect.Zero(t))
+		}
+		return nil
+	}
+
+	// if field is a nested struct, apply its own patch
+	value := change.Unwrap()
+	if isNestedStruct(t) {
+		nested, ok := asPatch(value)
+		if !ok {
+			return fmt.Errorf("apply %q: %T is not a patch", path, value)
+		}
+		return applyStruct(field, nested, path+".")
+	}
+
+	// otherwise, we have a new value, so convert it to the type of the field
+	target := t
+	if IsOptionType(t) {
+		target = ReflectValueType(t)
+	}
+	converted, err := convertValue(value, target)
+	if err != nil {
+		return fmt.Errorf("apply %q: %w", path, err)
+	}
+	if IsOptionType(t) {
+		ReflectSet(field, StateSome, converted)
+	} else {
+		field.Set(converted)
+	}
+	return nil
+}
+
+// asPatch returns the nested Patch held by a change, which is a map[string]any if the Patch was unmarshalled from JSON.
+func asPatch(value any) (Patch, bool) {
+	switch p := value.(type) {
+	case Patch:
+		return p, true
+	case map[string]any:
+		patch := make(Patch, len(p))
+		for k, v := range p {
+			if v == nil {
+				patch[k] = null[any]()
+			} else {
+				patch[k] = Option[any]{true: v}
+			}
+		}
+		return patch, true
+	default:
+		return nil, false
+	}
+}
+
+// isNestedStruct reports whether values of type t are handled field by field, by Diff and ToMap for example, rather
+// than as a whole like the types that marshal themselves.
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !t.Implements(jsonMarshalerType) && !t.Implements(textMarshalerType) &&
+		!reflect.PointerTo(t).Implements(jsonMarshalerType) && !reflect.PointerTo(t).Implements(textMarshalerType)
+}
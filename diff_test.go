@@ -0,0 +1,255 @@
+package optionalv2_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+type diffAddress struct {
+	City optionalv2.Option[string] `json:"city"`
+	Zip  string                    `json:"zip"`
+}
+
+type DiffMeta struct {
+	Version int `json:"version"`
+}
+
+type diffRecord struct {
+	DiffMeta
+	Name    string                     `json:"name"`
+	Age     int                        `json:"age"`
+	Email   optionalv2.Option[string]  `json:"email"`
+	Score   optionalv2.Option[float64] `json:"score"`
+	Tags    []string                   `json:"tags"`
+	Address diffAddress                `json:"address"`
+}
+
+type diffAccount struct {
+	Owner     optionalv2.Option[diffAddress] `json:"owner"`
+	CreatedAt time.Time                      `json:"createdAt"`
+	Internal  string                         `json:"-"`
+}
+
+type diffBalance struct {
+	Bal optionalv2.Option[cents] `json:"bal"`
+}
+
+type diffVersioned struct {
+	*DiffMeta
+	Name string `json:"name"`
+}
+
+// sameOption compares Options like Apply restores them: None and null are both cleared.
+func sameOption[T any](a, b optionalv2.Option[T]) bool {
+	return a.State() == optionalv2.StateSome == (b.State() == optionalv2.StateSome) &&
+		reflect.DeepEqual(a.Unwrap(), b.Unwrap())
+}
+
+func sameRecord(a, b diffRecord) bool {
+	return a.Version == b.Version && a.Name == b.Name && a.Age == b.Age &&
+		sameOption(a.Email, b.Email) && sameOption(a.Score, b.Score) &&
+		reflect.DeepEqual(a.Tags, b.Tags) &&
+		sameOption(a.Address.City, b.Address.City) && a.Address.Zip == b.Address.Zip
+}
+
+// onlyNulls reports whether every change of p, including in nested Patches, is a null.
+func onlyNulls(p optionalv2.Patch) bool {
+	for _, change := range p {
+		if nested, ok := change.Unwrap().(optionalv2.Patch); ok {
+			if !onlyNulls(nested) {
+				return false
+			}
+		} else if change.State() != optionalv2.StateNull {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDiff(t *testing.T) {
+	old := diffRecord{
+		DiffMeta: DiffMeta{Version: 1},
+		Name:     "Alice",
+		Age:      30,
+		Email:    optionalv2.Some("alice@example.com"),
+		Score:    optionalv2.Some(1.5),
+		Tags:     []string{"a"},
+		Address:  diffAddress{City: optionalv2.Some("Paris"), Zip: "75001"},
+	}
+
+	// Test unchanged fields are None, cleared fields null, and changed fields Some
+	t.Run("States", func(t *testing.T) {
+		changed := old
+		changed.Version = 2
+		changed.Age = 0
+		changed.Email = optionalv2.None[string]()
+		changed.Score = optionalv2.Some(2.5)
+		changed.Address.City = optionalv2.Some("Lyon")
+
+		patch, err := optionalv2.Diff(old, changed)
+		assert.NoError(t, err)
+		assert.Len(t, patch, 5)
+		assert.Equal(t, optionalv2.Some[any](2), patch["version"])
+		assert.Equal(t, optionalv2.StateNull, patch["age"].State())
+		assert.Equal(t, optionalv2.StateNull, patch["email"].State())
+		assert.Equal(t, optionalv2.Some[any](2.5), patch["score"])
+		assert.Equal(t, optionalv2.Some[any](optionalv2.Patch{"city": optionalv2.Some[any]("Lyon")}), patch["address"])
+		assert.True(t, patch["name"].IsNone())
+
+		data, err := json.Marshal(patch)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"version":2,"age":null,"email":null,"score":2.5,"address":{"city":"Lyon"}}`, string(data))
+	})
+
+	// Test identical values produce an empty Patch
+	t.Run("Unchanged", func(t *testing.T) {
+		patch, err := optionalv2.Diff(old, old)
+		assert.NoError(t, err)
+		assert.Empty(t, patch)
+	})
+
+	// Test Options of structs and types that marshal themselves are compared as a whole
+	t.Run("WholeValues", func(t *testing.T) {
+		before := diffAccount{CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Internal: "a"}
+		after := diffAccount{
+			Owner:     optionalv2.Some(diffAddress{City: optionalv2.Some("Oslo"), Zip: "1000"}),
+			CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			Internal:  "b",
+		}
+		patch, err := optionalv2.Diff(before, after)
+		assert.NoError(t, err)
+		assert.Equal(t, optionalv2.Patch{
+			"owner":     optionalv2.Some[any](diffAddress{City: optionalv2.Some("Oslo"), Zip: "1000"}),
+			"createdAt": optionalv2.Some[any](after.CreatedAt),
+		}, patch)
+
+		data, err := json.Marshal(patch)
+		assert.NoError(t, err)
+		var decoded optionalv2.Patch
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.NoError(t, optionalv2.Apply(&before, decoded))
+		after.Internal = "a"
+		assert.Equal(t, after, before)
+	})
+
+	// Test non-struct types and unknown fields are rejected
+	t.Run("Errors", func(t *testing.T) {
+		_, err := optionalv2.Diff(1, 2)
+		assert.ErrorIs(t, err, optionalv2.ErrNotStruct)
+
+		var r diffRecord
+		err = optionalv2.Apply(&r, optionalv2.Patch{
+			"nope":    optionalv2.Some[any](1),
+			"address": optionalv2.Some[any](map[string]any{"street": "x"}),
+			"age":     optionalv2.Some[any]("old"),
+		})
+		assert.ErrorIs(t, err, optionalv2.ErrUnknownPatchField)
+		assert.ErrorContains(t, err, `"nope"`)
+		assert.ErrorContains(t, err, `"address.street"`)
+		assert.ErrorContains(t, err, `apply "age"`)
+	})
+}
+
+func TestApply(t *testing.T) {
+	// Test Apply restores the new value, directly and through JSON
+	t.Run("RoundTrip", func(t *testing.T) {
+		property := func(old, new diffRecord, keep uint8) bool {
+			// keep some of the old fields, so that unchanged fields are exercised
+			if keep&1 != 0 {
+				new.Name = old.Name
+			}
+			if keep&2 != 0 {
+				new.Email = old.Email
+			}
+			if keep&4 != 0 {
+				new.Address = old.Address
+			}
+			if keep&8 != 0 {
+				new.Tags = old.Tags
+			}
+
+			patch, err := optionalv2.Diff(old, new)
+			if err != nil {
+				return false
+			}
+			direct := old
+			if err := optionalv2.Apply(&direct, patch); err != nil || !sameRecord(direct, new) {
+				return false
+			}
+
+			data, err := json.Marshal(patch)
+			if err != nil {
+				return false
+			}
+			var decoded optionalv2.Patch
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				return false
+			}
+			viaJSON := old
+			if err := optionalv2.Apply(&viaJSON, decoded); err != nil {
+				return false
+			}
+			return sameRecord(viaJSON, new)
+		}
+		assert.NoError(t, quick.Check(property, &quick.Config{MaxCount: 500}))
+	})
+
+	// Test a value that isn't null for its NullChecker survives the round trip, even if it is a zero value
+	t.Run("NullChecker", func(t *testing.T) {
+		patch, err := optionalv2.Diff(diffBalance{Bal: optionalv2.Some(cents(5))}, diffBalance{Bal: optionalv2.Some(cents(0))})
+		assert.NoError(t, err)
+		assert.Equal(t, optionalv2.StateSome, patch["bal"].State())
+		data, err := json.Marshal(patch)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"bal":0}`, string(data))
+
+		direct := diffBalance{Bal: optionalv2.Some(cents(5))}
+		assert.NoError(t, optionalv2.Apply(&direct, patch))
+		assert.Equal(t, optionalv2.Some(cents(0)), direct.Bal)
+
+		var decoded optionalv2.Patch
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		viaJSON := diffBalance{Bal: optionalv2.Some(cents(5))}
+		assert.NoError(t, optionalv2.Apply(&viaJSON, decoded))
+		assert.Equal(t, optionalv2.Some(cents(0)), viaJSON.Bal)
+	})
+
+	// Test the fields of an embedded pointer are flattened like encoding/json, and the pointer is allocated
+	t.Run("EmbeddedPointer", func(t *testing.T) {
+		patch, err := optionalv2.Diff(diffVersioned{Name: "a"}, diffVersioned{DiffMeta: &DiffMeta{Version: 2}, Name: "a"})
+		assert.NoError(t, err)
+		data, err := json.Marshal(patch)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"version":2}`, string(data))
+
+		var v diffVersioned
+		assert.NoError(t, optionalv2.Apply(&v, patch))
+		assert.Equal(t, &DiffMeta{Version: 2}, v.DiffMeta)
+	})
+
+	// Test Diff of the applied value is empty
+	t.Run("Idempotent", func(t *testing.T) {
+		property := func(old, new diffRecord) bool {
+			patch, err := optionalv2.Diff(old, new)
+			if err != nil {
+				return false
+			}
+			if err := optionalv2.Apply(&old, patch); err != nil {
+				return false
+			}
+			again, err := optionalv2.Diff(old, new)
+			if err != nil {
+				return false
+			}
+			// only Options that became None and were restored as null may differ
+			return onlyNulls(again)
+		}
+		assert.NoError(t, quick.Check(property, nil))
+	})
+}
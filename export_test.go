@@ -0,0 +1,6 @@
+package optionalv2
+
+// IsNullValue exposes the check Some does to the benchmarks, which would otherwise mostly measure the map allocation.
+func IsNullValue[T any](v T) bool {
+	return isNullValue(&v)
+}
@@ -0,0 +1,62 @@
+package optionalv2
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/tapp-ai/go-optional-v2/internal/structfields"
+)
+
+// jsonField is a struct field named like encoding/json does.
+type jsonField struct {
+	structfields.Field
+	omitEmpty bool
+}
+
+// jsonFields returns the exported fields of the struct type t, named after their `json` tag (falling back to the Go
+// field name) and skipping `json:"-"`. Embedded structs and pointers to structs are flattened like encoding/json does
+// (see structfields.Fields).
+func jsonFields(t reflect.Type) []jsonField {
+	fields := structfields.Fields(t, structfields.Tag("json"))
+	jf := make([]jsonField, len(fields))
+	for i, f := range fields {
+		_, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		jf[i] = jsonField{Field: f, omitEmpty: strings.Contains(","+opts+",", ",omitempty,")}
+	}
+	return jf
+}
+
+// jsonFieldValues returns the fields of the struct v by name (see jsonFields).
+// Fields promoted through a nil embedded pointer have the zero value of their type.
+func jsonFieldValues(v reflect.Value) map[string]reflect.Value {
+	fields := jsonFields(v.Type())
+	values := make(map[string]reflect.Value, len(fields))
+	for _, f := range fields {
+		fv, ok := f.Value(v)
+		if !ok {
+			fv = reflect.Zero(f.Type)
+		}
+		values[f.Name] = fv
+	}
+	return values
+}
+
+// convertValue converts value to the type target, going through JSON if it isn't assignable (e.g. a float64 or a
+// map[string]any unmarshalled from JSON).
+func convertValue(value any, target reflect.Type) (reflect.Value, error) {
+	converted := reflect.New(target).Elem()
+	if rv := reflect.ValueOf(value); rv.IsValid() && rv.Type().AssignableTo(target) {
+		converted.Set(rv)
+		return converted, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if err := json.Unmarshal(data, converted.Addr().Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return converted, nil
+}
@@ -0,0 +1,85 @@
+package optionalv2
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+const (
+	// NonePlaceholder is printed in place of the value of a None Option by the `%v` verb (and other value verbs).
+	NonePlaceholder = "<none>"
+	// NullPlaceholder is printed in place of the value of a null Option by the `%v` verb (and other value verbs).
+	NullPlaceholder = "<null>"
+)
+
+// Format implements the fmt.Formatter interface for Option.
+//   - `%v` and the other verbs print the value of Some, forwarding the flags, width and precision (e.g. `%6.2f`).
+//     None and null print NonePlaceholder and NullPlaceholder instead (see WithPlaceholders to change them).
+//   - `%+v` prints the state and the value, like String (i.e. `Some[42]`, `Null[]` or `None[]`).
+//   - `%#v` prints Go syntax, like GoString (e.g. `optionalv2.Some[int](42)`).
+func (o Option[T]) Format(f fmt.State, verb rune) {
+	o.format(f, verb, NonePlaceholder, NullPlaceholder)
+}
+
+// WithPlaceholders returns a fmt.Formatter that formats o like Format, but prints none and null in place of the value
+// of a None and a null Option. The placeholders are passed per call rather than set globally, so that concurrent
+// formatting can't observe them changing.
+//
+//	fmt.Printf("%v", optionalv2.WithPlaceholders(o, "-", "NULL"))
+func WithPlaceholders[T any](o Option[T], none, null string) fmt.Formatter {
+	return placeholderFormatter[T]{option: o, none: none, null: null}
+}
+
+type placeholderFormatter[T any] struct {
+	option     Option[T]
+	none, null string
+}
+
+func (p placeholderFormatter[T]) Format(f fmt.State, verb rune) {
+	p.option.format(f, verb, p.none, p.null)
+}
+
+func (o Option[T]) format(f fmt.State, verb rune, none, null string) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		_, _ = io.WriteString(f, o.GoString())
+	case verb == 'v' && f.Flag('+'):
+		writePadded(f, o.String())
+	case o.IsNone():
+		writePadded(f, none)
+	case o.isNull():
+		writePadded(f, null)
+	default:
+		_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), o[true])
+	}
+}
+
+// GoString implements the fmt.GoStringer interface for Option.
+// It returns the Go syntax that constructs the Option, e.g. `optionalv2.Some[int](42)` or `optionalv2.None[int]()`.
+// A null Option is printed as Some of the zero value, which is how a null is constructed.
+func (o Option[T]) GoString() string {
+	typeName := reflect.TypeOf((*T)(nil)).Elem().String()
+	if o.IsNone() {
+		return fmt.Sprintf("optionalv2.None[%s]()", typeName)
+	}
+
+	v := interface{}(o.Unwrap())
+	if v == nil {
+		return fmt.Sprintf("optionalv2.Some[%s](nil)", typeName)
+	}
+	return fmt.Sprintf("optionalv2.Some[%s](%#v)", typeName, v)
+}
+
+// writePadded writes s honoring the width and the `-` flag of f, but not the precision, which only applies to values.
+func writePadded(f fmt.State, s string) {
+	format := "%"
+	if f.Flag('-') {
+		format += "-"
+	}
+	if width, ok := f.Width(); ok {
+		format += strconv.Itoa(width)
+	}
+	_, _ = fmt.Fprintf(f, format+"s", s)
+}
@@ -0,0 +1,75 @@
+package optionalv2_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+func TestFormat(t *testing.T) {
+	// Test String tells null apart from Some
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, "Some[42]", optionalv2.Some(42).String())
+		assert.Equal(t, "Null[]", optionalv2.Some(0).String())
+		assert.Equal(t, "None[]", optionalv2.None[int]().String())
+	})
+
+	// Test %v prints the value or a placeholder
+	t.Run("Value", func(t *testing.T) {
+		assert.Equal(t, "42", fmt.Sprintf("%v", optionalv2.Some(42)))
+		assert.Equal(t, "<null>", fmt.Sprintf("%v", optionalv2.Some(0)))
+		assert.Equal(t, "<none>", fmt.Sprintf("%v", optionalv2.None[int]()))
+		assert.Equal(t, "CustomType(ID=1, Name=Test)", fmt.Sprintf("%v", optionalv2.Some(CustomType{ID: 1, Name: "Test"})))
+		assert.Equal(t, "[1 2]", fmt.Sprint(optionalv2.Some([]int{1, 2})))
+
+		type user struct {
+			Name optionalv2.Option[string]
+			Age  optionalv2.Option[int]
+		}
+		assert.Equal(t, "{Alice <none>}", fmt.Sprintf("%v", user{Name: optionalv2.Some("Alice"), Age: optionalv2.None[int]()}))
+	})
+
+	// Test the placeholders can be changed per call
+	t.Run("Placeholders", func(t *testing.T) {
+		assert.Equal(t, "- NULL 42", fmt.Sprintf("%v %v %v",
+			optionalv2.WithPlaceholders(optionalv2.None[string](), "-", "NULL"),
+			optionalv2.WithPlaceholders(optionalv2.Some(""), "-", "NULL"),
+			optionalv2.WithPlaceholders(optionalv2.Some(42), "-", "NULL")))
+		assert.Equal(t, "   -|", fmt.Sprintf("%4v|", optionalv2.WithPlaceholders(optionalv2.None[int](), "-", "NULL")))
+		assert.Equal(t, "None[]", fmt.Sprintf("%+v", optionalv2.WithPlaceholders(optionalv2.None[int](), "-", "NULL")))
+		assert.Equal(t, "<none>", fmt.Sprintf("%v", optionalv2.None[string]()))
+	})
+
+	// Test %+v prints the state
+	t.Run("PlusValue", func(t *testing.T) {
+		assert.Equal(t, "Some[42]", fmt.Sprintf("%+v", optionalv2.Some(42)))
+		assert.Equal(t, "Null[]", fmt.Sprintf("%+v", optionalv2.Some(0)))
+		assert.Equal(t, "None[]", fmt.Sprintf("%+v", optionalv2.None[int]()))
+		assert.Equal(t, "  None[]", fmt.Sprintf("%+8v", optionalv2.None[int]()))
+	})
+
+	// Test %#v prints Go syntax
+	t.Run("GoSyntax", func(t *testing.T) {
+		assert.Equal(t, "optionalv2.Some[int](42)", fmt.Sprintf("%#v", optionalv2.Some(42)))
+		assert.Equal(t, "optionalv2.Some[int](0)", fmt.Sprintf("%#v", optionalv2.Some(0)))
+		assert.Equal(t, "optionalv2.None[int]()", fmt.Sprintf("%#v", optionalv2.None[int]()))
+		assert.Equal(t, `optionalv2.Some[string]("a")`, fmt.Sprintf("%#v", optionalv2.Some("a")))
+		assert.Equal(t, `optionalv2.Some[[]string]([]string{"a"})`, optionalv2.Some([]string{"a"}).GoString())
+		assert.Equal(t, "optionalv2.Some[interface {}](nil)", fmt.Sprintf("%#v", optionalv2.Some[any](0)))
+		assert.Equal(t, "optionalv2.None[time.Duration]()", fmt.Sprintf("%#v", optionalv2.None[time.Duration]()))
+	})
+
+	// Test width, precision and other verbs are forwarded to the value
+	t.Run("Verbs", func(t *testing.T) {
+		assert.Equal(t, "  3.14", fmt.Sprintf("%6.2f", optionalv2.Some(3.14159)))
+		assert.Equal(t, "002a", fmt.Sprintf("%04x", optionalv2.Some(42)))
+		assert.Equal(t, `"hi"`, fmt.Sprintf("%q", optionalv2.Some("hi")))
+		assert.Equal(t, "hi   |", fmt.Sprintf("%-5s|", optionalv2.Some("hi")))
+		assert.Equal(t, "1s", fmt.Sprintf("%s", optionalv2.Some(time.Second)))
+		assert.Equal(t, "  <none>", fmt.Sprintf("%8d", optionalv2.None[int]()))
+		assert.Equal(t, "<null>", fmt.Sprintf("%.2f", optionalv2.Some(0.0)))
+	})
+}
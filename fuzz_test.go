@@ -0,0 +1,126 @@
+package optionalv2_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"unicode/utf8"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+type fuzzRecord struct {
+	Name   optionalv2.Option[string]                    `json:"name,omitempty"`
+	Count  optionalv2.Option[int64]                     `json:"count,omitempty"`
+	Tags   optionalv2.Option[[]string]                  `json:"tags,omitempty"`
+	Nested optionalv2.Option[optionalv2.Option[string]] `json:"nested,omitempty"`
+}
+
+// checkUnmarshalJSON checks the documented invariants of unmarshalling data into an Option[T].
+func checkUnmarshalJSON[T any](t *testing.T, data []byte) {
+	var o optionalv2.Option[T]
+	if err := json.Unmarshal(data, &o); err != nil {
+		return
+	}
+
+	// UnmarshalJSON is only called for present values, so the Option can't be None
+	if o.IsNone() {
+		t.Fatalf("unmarshalling %q produced None", data)
+	}
+	// a JSON null is an explicit null
+	if bytes.Equal(bytes.TrimSpace(data), optionalv2.NullBytes) && o.State() != optionalv2.StateNull {
+		t.Fatalf("unmarshalling %q produced %s", data, o.State())
+	}
+
+	// marshalling is stable after the first round trip
+	first, err := json.Marshal(o)
+	if err != nil {
+		t.Fatalf("marshalling %v: %v", o, err)
+	}
+	if o.State() == optionalv2.StateNull && !bytes.Equal(first, optionalv2.NullBytes) {
+		t.Fatalf("null marshalled as %q", first)
+	}
+	var again optionalv2.Option[T]
+	if err := json.Unmarshal(first, &again); err != nil {
+		t.Fatalf("unmarshalling %q: %v", first, err)
+	}
+	second, err := json.Marshal(again)
+	if err != nil {
+		t.Fatalf("marshalling %v: %v", again, err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Fatalf("round trip of %q is not stable: %q != %q", data, first, second)
+	}
+}
+
+func FuzzUnmarshalJSON(f *testing.F) {
+	for _, seed := range []string{`null`, `0`, `42`, `""`, `"text"`, `[]`, `["a",null]`, `{"name":"x"}`, `"é😀"`} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		checkUnmarshalJSON[int64](t, data)
+		checkUnmarshalJSON[string](t, data)
+		checkUnmarshalJSON[[]optionalv2.Option[int]](t, data)
+		checkUnmarshalJSON[optionalv2.Option[string]](t, data)
+		checkUnmarshalJSON[fuzzRecord](t, data)
+		checkUnmarshalJSON[any](t, data)
+	})
+}
+
+// fuzzOption builds an Option in the state selected by state.
+func fuzzOption[T any](state uint8, v T) optionalv2.Option[T] {
+	switch state % 3 {
+	case 0:
+		return optionalv2.None[T]()
+	case 1:
+		var zero T
+		return optionalv2.Some(zero)
+	default:
+		return optionalv2.Some(v)
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add("Alice", int64(42), uint8(0x2a))
+	f.Add("", int64(0), uint8(0x15))
+	f.Add("é\U0001f600", int64(-1), uint8(0))
+	f.Add("null", int64(1<<53+1), uint8(0xff))
+
+	f.Fuzz(func(t *testing.T, name string, count int64, states uint8) {
+		if !utf8.ValidString(name) {
+			// encoding/json replaces invalid UTF-8, so the value can't round trip
+			t.Skip()
+		}
+
+		in := fuzzRecord{
+			Name:   fuzzOption(states, name),
+			Count:  fuzzOption(states>>2, count),
+			Tags:   fuzzOption(states>>4, []string{name}),
+			Nested: fuzzOption(states>>6, optionalv2.Some(name)),
+		}
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("marshalling %+v: %v", in, err)
+		}
+
+		var out fuzzRecord
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("unmarshalling %q: %v", data, err)
+		}
+
+		if !optionalv2.Equal(in.Name, out.Name) {
+			t.Fatalf("name: %v != %v (%s)", in.Name, out.Name, data)
+		}
+		if !optionalv2.Equal(in.Count, out.Count) {
+			t.Fatalf("count: %v != %v (%s)", in.Count, out.Count, data)
+		}
+		if in.Tags.State() != out.Tags.State() {
+			t.Fatalf("tags: %v != %v (%s)", in.Tags, out.Tags, data)
+		}
+		// a Some holding a null Option is marshalled as null, so only the outer presence survives
+		if in.Nested.IsNone() != out.Nested.IsNone() {
+			t.Fatalf("nested: %v != %v (%s)", in.Nested, out.Nested, data)
+		}
+	})
+}
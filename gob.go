@@ -0,0 +1,71 @@
+package optionalv2
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// gobVersion is the version of the wire format written by GobEncode.
+// Bump it (and keep decoding the old versions) whenever the format changes.
+const gobVersion byte = 1
+
+// Gob states, written as the second byte of the wire format.
+const (
+	gobStateNone byte = iota
+	gobStateNull
+	gobStateSome
+)
+
+// ErrInvalidGobData represents the error that is raised when GobDecode receives malformed data.
+var ErrInvalidGobData = errors.New("invalid gob data for option")
+
+// GobEncode implements the gob.GobEncoder interface for Option.
+// The wire format is a version byte, a state byte (None, null or Some) and, for Some, the gob encoding of the value.
+// This keeps the encoding independent of the internal representation of Option.
+func (o Option[T]) GobEncode() ([]byte, error) {
+	switch {
+	case o.IsNone():
+		return []byte{gobVersion, gobStateNone}, nil
+	case o.isNull():
+		return []byte{gobVersion, gobStateNull}, nil
+	}
+
+	// encode a pointer, so that interface types are sent with their concrete type like GobDecode expects
+	v := o[true]
+	buf := bytes.NewBuffer([]byte{gobVersion, gobStateSome})
+	if err := gob.NewEncoder(buf).Encode(&v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface for Option.
+func (o *Option[T]) GobDecode(data []byte) error {
+	if len(data) < 2 {
+		return ErrInvalidGobData
+	}
+	if data[0] != gobVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidGobData, data[0])
+	}
+
+	switch data[1] {
+	case gobStateNone:
+		*o = None[T]()
+		return nil
+	case gobStateNull:
+		*o = null[T]()
+		return nil
+	case gobStateSome:
+		var v T
+		if err := gob.NewDecoder(bytes.NewReader(data[2:])).Decode(&v); err != nil {
+			return err
+		}
+		// the value was Some when it was encoded, so keep it as Some even if gob decodes it as a zero value (e.g. empty slices)
+		*o = Option[T]{true: v}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown state %d", ErrInvalidGobData, data[1])
+	}
+}
@@ -0,0 +1,173 @@
+package optionalv2_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+type gobRecord struct {
+	Name optionalv2.Option[string]
+	Age  optionalv2.Option[int]
+	Tags optionalv2.Option[[]string]
+}
+
+// legacyGobRecord mirrors gobRecord as it was encoded before Option implemented gob.GobEncoder.
+type legacyGobRecord struct {
+	Name map[bool]string
+	Age  map[bool]int
+	Tags map[bool][]string
+}
+
+func readGobFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "gob", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func gobRoundTrip[T any](t *testing.T, in T) T {
+	t.Helper()
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(in))
+	var out T
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&out))
+	return out
+}
+
+func TestGob(t *testing.T) {
+	// Test all three states survive a round trip
+	t.Run("RoundTrip", func(t *testing.T) {
+		out := gobRoundTrip(t, optionalv2.Some(42))
+		assert.True(t, out.IsSome())
+		assert.Equal(t, 42, out.Unwrap())
+
+		out = gobRoundTrip(t, optionalv2.Some(0))
+		assert.True(t, out.IsSome())
+		assert.Equal(t, 0, out.Unwrap())
+		data, err := out.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, "null", string(data))
+
+		out = gobRoundTrip(t, optionalv2.None[int]())
+		assert.True(t, out.IsNone())
+	})
+
+	// Test Options nested in a struct
+	t.Run("StructFields", func(t *testing.T) {
+		out := gobRoundTrip(t, gobRecord{
+			Name: optionalv2.Some("Alice"),
+			Age:  optionalv2.Some(0),
+			Tags: optionalv2.None[[]string](),
+		})
+		assert.Equal(t, "Alice", out.Name.Unwrap())
+		assert.True(t, out.Age.IsSome())
+		assert.Equal(t, 0, out.Age.Unwrap())
+		assert.True(t, out.Tags.IsNone())
+
+		// zero-valued Option fields are omitted by gob and decoded as None
+		out = gobRoundTrip(t, gobRecord{Name: optionalv2.Some("Bob")})
+		assert.Equal(t, "Bob", out.Name.Unwrap())
+		assert.True(t, out.Age.IsNone())
+		assert.True(t, out.Tags.IsNone())
+	})
+
+	// Test that an empty but non-nil value stays Some
+	t.Run("EmptySlice", func(t *testing.T) {
+		opt := optionalv2.Option[[]string]{true: []string{}}
+		out := gobRoundTrip(t, opt)
+		assert.True(t, out.IsSome())
+		assert.Empty(t, out.Unwrap())
+	})
+
+	// Test interface values are decoded with their concrete type
+	t.Run("Interface", func(t *testing.T) {
+		out := gobRoundTrip(t, optionalv2.Some[any](42))
+		assert.True(t, out.IsSome())
+		assert.Equal(t, 42, out.Unwrap())
+
+		out = gobRoundTrip(t, optionalv2.Some[any]("a"))
+		assert.Equal(t, "a", out.Unwrap())
+
+		out = gobRoundTrip(t, optionalv2.None[any]())
+		assert.True(t, out.IsNone())
+	})
+
+	// Test the wire format is stable by decoding checked-in fixtures
+	t.Run("Fixtures", func(t *testing.T) {
+		var rec gobRecord
+		assert.NoError(t, gob.NewDecoder(bytes.NewReader(readGobFixture(t, "v1_some.gob"))).Decode(&rec))
+		assert.Equal(t, "Alice", rec.Name.Unwrap())
+		assert.Equal(t, 42, rec.Age.Unwrap())
+		assert.Equal(t, []string{"a", "b"}, rec.Tags.Unwrap())
+
+		rec = gobRecord{}
+		assert.NoError(t, gob.NewDecoder(bytes.NewReader(readGobFixture(t, "v1_null.gob"))).Decode(&rec))
+		assert.True(t, rec.Name.IsSome())
+		assert.Equal(t, "", rec.Name.Unwrap())
+		assert.True(t, rec.Age.IsSome())
+		assert.Equal(t, 0, rec.Age.Unwrap())
+		assert.True(t, rec.Tags.IsSome())
+		assert.Nil(t, rec.Tags.Unwrap())
+
+		rec = gobRecord{}
+		assert.NoError(t, gob.NewDecoder(bytes.NewReader(readGobFixture(t, "v1_none.gob"))).Decode(&rec))
+		assert.True(t, rec.Name.IsNone())
+		assert.True(t, rec.Age.IsNone())
+		assert.True(t, rec.Tags.IsNone())
+	})
+
+	// Test fixtures written with the map-based encoding can't be decoded into Option fields anymore
+	t.Run("LegacyFixturesIncompatible", func(t *testing.T) {
+		for _, name := range []string{"legacy_some.gob", "legacy_null.gob", "legacy_none.gob"} {
+			var rec gobRecord
+			err := gob.NewDecoder(bytes.NewReader(readGobFixture(t, name))).Decode(&rec)
+			assert.ErrorContains(t, err, "gob: wrong type (optionalv2.Option[string]) for received field gobRecord.Name", name)
+		}
+	})
+
+	// Test the migration path for data written with the map-based encoding: decode it into a map[bool]T mirror and
+	// convert it. This isn't a compatibility guarantee, since the mirror struct must be written by hand.
+	t.Run("LegacyMigration", func(t *testing.T) {
+		var legacy legacyGobRecord
+		assert.NoError(t, gob.NewDecoder(bytes.NewReader(readGobFixture(t, "legacy_some.gob"))).Decode(&legacy))
+		assert.Equal(t, "Alice", optionalv2.Option[string](legacy.Name).Unwrap())
+		assert.Equal(t, 42, optionalv2.Option[int](legacy.Age).Unwrap())
+		assert.Equal(t, []string{"a", "b"}, optionalv2.Option[[]string](legacy.Tags).Unwrap())
+
+		legacy = legacyGobRecord{}
+		assert.NoError(t, gob.NewDecoder(bytes.NewReader(readGobFixture(t, "legacy_null.gob"))).Decode(&legacy))
+		age := optionalv2.Option[int](legacy.Age)
+		assert.True(t, age.IsSome())
+		data, err := age.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, "null", string(data))
+
+		// re-encoding the converted value uses the versioned format
+		out := gobRoundTrip(t, age)
+		assert.True(t, out.IsSome())
+		assert.Equal(t, 0, out.Unwrap())
+
+		legacy = legacyGobRecord{}
+		assert.NoError(t, gob.NewDecoder(bytes.NewReader(readGobFixture(t, "legacy_none.gob"))).Decode(&legacy))
+		assert.True(t, optionalv2.Option[string](legacy.Name).IsNone())
+		assert.True(t, optionalv2.Option[int](legacy.Age).IsNone())
+		assert.True(t, optionalv2.Option[[]string](legacy.Tags).IsNone())
+	})
+
+	// Test malformed data is rejected
+	t.Run("InvalidData", func(t *testing.T) {
+		var opt optionalv2.Option[int]
+		assert.ErrorIs(t, opt.GobDecode(nil), optionalv2.ErrInvalidGobData)
+		assert.ErrorIs(t, opt.GobDecode([]byte{99, 0}), optionalv2.ErrInvalidGobData)
+		assert.ErrorIs(t, opt.GobDecode([]byte{1, 99}), optionalv2.ErrInvalidGobData)
+		assert.Error(t, opt.GobDecode([]byte{1, 2, 0xff}))
+	})
+}
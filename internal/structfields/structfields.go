@@ -0,0 +1,139 @@
+// Package structfields lists the fields of structs the way encoding/json does.
+// It is shared by the encoders and decoders that name struct fields after a tag, such as patches, maps, field masks,
+// form values and GraphQL inputs, so that they agree on which fields exist and how embedded structs are flattened.
+package structfields
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrUnexportedEmbedded represents the error that is raised when a field promoted through a nil pointer to an
+// unexported embedded struct is set, since the pointer can't be allocated.
+var ErrUnexportedEmbedded = errors.New("cannot set embedded pointer to unexported struct")
+
+// Namer returns the name of a struct field, or false if the field is skipped.
+// An empty name falls back to the Go field name, and flattens the field if it embeds a struct or a pointer to a struct.
+type Namer func(sf reflect.StructField) (name string, ok bool)
+
+// Tag returns a Namer that names fields after the given struct tag like encoding/json: the part of the tag before the
+// first comma, with `-` skipping the field. An empty key names fields after their Go name.
+func Tag(key string) Namer {
+	return func(sf reflect.StructField) (string, bool) {
+		if key == "" {
+			return "", true
+		}
+		tag := sf.Tag.Get(key)
+		if tag == "-" {
+			return "", false
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		return name, true
+	}
+}
+
+// Field is an exported field of a struct, possibly promoted from an embedded struct.
+type Field struct {
+	// Name is the name of the field given by the Namer, or its Go name.
+	Name string
+	// Type is the type of the field.
+	Type reflect.Type
+	// Tag is the tag of the field.
+	Tag reflect.StructTag
+	// Index is the index sequence of the field, as for reflect.Value.FieldByIndex.
+	Index []int
+}
+
+// Fields returns the exported fields of the struct type t, named by name.
+// Embedded structs and pointers to structs are flattened, and like encoding/json, the fields of a struct win over the
+// fields with the same name promoted from its embedded structs. Fields are returned in declaration order.
+func Fields(t reflect.Type, name Namer) []Field {
+	return fields(t, name, nil, map[reflect.Type]bool{})
+}
+
+func fields(t reflect.Type, name Namer, index []int, walking map[reflect.Type]bool) []Field {
+	// an embedded pointer to a struct that is already being walked would recurse forever
+	walking[t] = true
+	defer delete(walking, t)
+
+	var direct, promoted []Field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fieldName, ok := name(sf)
+		if !ok {
+			continue
+		}
+		fieldIndex := append(index[:len(index):len(index)], i)
+
+		if sf.Anonymous && fieldName == "" {
+			et := sf.Type
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				if !walking[et] {
+					promoted = append(promoted, fields(et, name, fieldIndex, walking)...)
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if fieldName == "" {
+			fieldName = sf.Name
+		}
+		direct = append(direct, Field{Name: fieldName, Type: sf.Type, Tag: sf.Tag, Index: fieldIndex})
+	}
+
+	seen := make(map[string]bool, len(direct))
+	for _, f := range direct {
+		seen[f.Name] = true
+	}
+	for _, f := range promoted {
+		if !seen[f.Name] {
+			seen[f.Name] = true
+			direct = append(direct, f)
+		}
+	}
+
+	// like encoding/json, keep the promoted fields where the embedded struct is declared
+	slices.SortFunc(direct, func(a, b Field) int {
+		return slices.Compare(a.Index, b.Index)
+	})
+	return direct
+}
+
+// Value returns the field of the struct v, or false if it is promoted through a nil embedded pointer.
+func (f Field) Value(v reflect.Value) (reflect.Value, bool) {
+	for i, x := range f.Index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// Alloc is like Value, but allocates the nil embedded pointers the field is promoted through, so that it can be set.
+// It returns ErrUnexportedEmbedded if one of them embeds an unexported struct type.
+func (f Field) Alloc(v reflect.Value) (reflect.Value, error) {
+	for i, x := range f.Index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, ErrUnexportedEmbedded
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
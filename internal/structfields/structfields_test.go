@@ -0,0 +1,98 @@
+package structfields_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tapp-ai/go-optional-v2/internal/structfields"
+)
+
+type Base struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type audit struct {
+	CreatedBy string `json:"createdBy"`
+}
+
+type Node struct {
+	*Node
+	Value int `json:"value"`
+}
+
+type record struct {
+	Name string `json:"title,omitempty"`
+	*Base
+	audit
+	*unexported
+	Tagged  Base `json:"tagged"`
+	Skipped int  `json:"-"`
+	hidden  int
+	Plain   bool
+}
+
+type unexported struct {
+	Hidden string `json:"hiddenField"`
+}
+
+func names(fields []structfields.Field) []string {
+	var names []string
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+func TestStructfields(t *testing.T) {
+	// Test fields are named after the tag, flattened, and direct fields win over promoted ones
+	t.Run("Fields", func(t *testing.T) {
+		fields := structfields.Fields(reflect.TypeOf(record{}), structfields.Tag("json"))
+		assert.Equal(t, []string{"title", "id", "name", "createdBy", "hiddenField", "tagged", "Plain"}, names(fields))
+		assert.Equal(t, []int{1, 0}, fields[1].Index)
+		assert.Equal(t, reflect.TypeOf(0), fields[1].Type)
+		assert.Equal(t, `json:"title,omitempty"`, string(fields[0].Tag))
+
+		type shadowed struct {
+			Base
+			Name string `json:"name"`
+		}
+		fields = structfields.Fields(reflect.TypeOf(shadowed{}), structfields.Tag("json"))
+		assert.Equal(t, []string{"id", "name"}, names(fields))
+		assert.Equal(t, []int{1}, fields[1].Index)
+	})
+
+	// Test an empty tag key names fields after their Go name
+	t.Run("GoNames", func(t *testing.T) {
+		fields := structfields.Fields(reflect.TypeOf(record{}), structfields.Tag(""))
+		assert.Equal(t, []string{"Name", "ID", "CreatedBy", "Hidden", "Tagged", "Skipped", "Plain"}, names(fields))
+	})
+
+	// Test a struct embedding a pointer to itself doesn't recurse forever
+	t.Run("Cycle", func(t *testing.T) {
+		assert.Equal(t, []string{"value"}, names(structfields.Fields(reflect.TypeOf(Node{}), structfields.Tag("json"))))
+	})
+
+	// Test fields promoted through nil pointers are reported and allocated
+	t.Run("ValueAndAlloc", func(t *testing.T) {
+		fields := structfields.Fields(reflect.TypeOf(record{}), structfields.Tag("json"))
+		var r record
+		v := reflect.ValueOf(&r).Elem()
+
+		_, ok := fields[1].Value(v)
+		assert.False(t, ok)
+		title, ok := fields[0].Value(v)
+		assert.True(t, ok)
+		title.SetString("a")
+		assert.Equal(t, "a", r.Name)
+
+		id, err := fields[1].Alloc(v)
+		assert.NoError(t, err)
+		id.SetInt(7)
+		assert.Equal(t, 7, r.Base.ID)
+
+		_, err = fields[4].Alloc(v)
+		assert.ErrorIs(t, err, structfields.ErrUnexportedEmbedded)
+	})
+}
@@ -0,0 +1,148 @@
+// Package textconv converts between strings and Go values.
+// It is shared by the encoders and decoders that read Option values from text, such as XML attributes, query strings,
+// environment variables and command-line flags.
+package textconv
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ErrUnsupportedType represents the error that is raised when a type cannot be converted from or to text.
+var ErrUnsupportedType = errors.New("unsupported type")
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+	urlType             = reflect.TypeOf(url.URL{})
+)
+
+// Supports reports whether values of type t can be parsed from and formatted as text.
+func Supports(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) || t == durationType || t == urlType {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return Supports(t.Elem())
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// Parse parses s and stores the result in dst, which must be settable.
+// Types implementing encoding.TextUnmarshaler are parsed with UnmarshalText.
+func Parse(s string, dst reflect.Value) error {
+	t := dst.Type()
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch t {
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		dst.SetInt(int64(d))
+		return nil
+	case urlType:
+		u, err := url.Parse(s)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(*u))
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		v := reflect.New(t.Elem())
+		if err := Parse(s, v.Elem()); err != nil {
+			return err
+		}
+		dst.Set(v)
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+	}
+	return nil
+}
+
+// Format formats v as text.
+// Types implementing encoding.TextMarshaler are formatted with MarshalText.
+func Format(v reflect.Value) (string, error) {
+	t := v.Type()
+	if t.Implements(textMarshalerType) {
+		if t.Kind() == reflect.Pointer && v.IsNil() {
+			return "", nil
+		}
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch t {
+	case durationType:
+		return time.Duration(v.Int()).String(), nil
+	case urlType:
+		u := v.Interface().(url.URL)
+		return u.String(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return "", nil
+		}
+		return Format(v.Elem())
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, t.Bits()), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+	}
+}
@@ -0,0 +1,92 @@
+package textconv_test
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tapp-ai/go-optional-v2/internal/textconv"
+)
+
+func parse[T any](t *testing.T, s string) (T, error) {
+	t.Helper()
+	var v T
+	err := textconv.Parse(s, reflect.ValueOf(&v).Elem())
+	return v, err
+}
+
+func format[T any](t *testing.T, v T) string {
+	t.Helper()
+	s, err := textconv.Format(reflect.ValueOf(&v).Elem())
+	assert.NoError(t, err)
+	return s
+}
+
+func TestTextconv(t *testing.T) {
+	// Test parsing primitives and well-known types
+	t.Run("Parse", func(t *testing.T) {
+		i, err := parse[int16](t, "-12")
+		assert.NoError(t, err)
+		assert.Equal(t, int16(-12), i)
+
+		u, err := parse[uint](t, "12")
+		assert.NoError(t, err)
+		assert.Equal(t, uint(12), u)
+
+		f, err := parse[float64](t, "1.5")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.5, f)
+
+		b, err := parse[bool](t, "true")
+		assert.NoError(t, err)
+		assert.True(t, b)
+
+		d, err := parse[time.Duration](t, "1m30s")
+		assert.NoError(t, err)
+		assert.Equal(t, 90*time.Second, d)
+
+		tm, err := parse[time.Time](t, "2024-09-13T00:00:00Z")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC), tm)
+
+		link, err := parse[url.URL](t, "https://example.com/a?b=c")
+		assert.NoError(t, err)
+		assert.Equal(t, "example.com", link.Host)
+
+		p, err := parse[*int](t, "3")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, *p)
+
+		_, err = parse[int8](t, "300")
+		assert.Error(t, err)
+
+		_, err = parse[[]int](t, "1")
+		assert.ErrorIs(t, err, textconv.ErrUnsupportedType)
+	})
+
+	// Test formatting primitives and well-known types
+	t.Run("Format", func(t *testing.T) {
+		assert.Equal(t, "-12", format(t, -12))
+		assert.Equal(t, "1.5", format(t, 1.5))
+		assert.Equal(t, "false", format(t, false))
+		assert.Equal(t, "1m30s", format(t, 90*time.Second))
+		assert.Equal(t, "2024-09-13T00:00:00Z", format(t, time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, "https://example.com", format(t, url.URL{Scheme: "https", Host: "example.com"}))
+		assert.Equal(t, "", format[*int](t, nil))
+
+		_, err := textconv.Format(reflect.ValueOf(map[string]int{}))
+		assert.ErrorIs(t, err, textconv.ErrUnsupportedType)
+	})
+
+	// Test Supports
+	t.Run("Supports", func(t *testing.T) {
+		assert.True(t, textconv.Supports(reflect.TypeOf(0)))
+		assert.True(t, textconv.Supports(reflect.TypeOf(time.Time{})))
+		assert.True(t, textconv.Supports(reflect.TypeOf(url.URL{})))
+		assert.True(t, textconv.Supports(reflect.TypeOf(new(string))))
+		assert.False(t, textconv.Supports(reflect.TypeOf([]int{})))
+		assert.False(t, textconv.Supports(reflect.TypeOf(struct{}{})))
+	})
+}
@@ -0,0 +1,101 @@
+package optionalv2
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// LazyOptions configures NewLazyWith.
+type LazyOptions struct {
+	// RetryNone makes a Lazy call its function again on the next access if it returned None, e.g. to retry a lookup
+	// that found nothing. By default, the first result is kept whatever its state.
+	RetryNone bool
+}
+
+// Lazy is an Option whose value is computed by a function on first access, at most once, like sync.OnceValue.
+// It is safe for concurrent use: concurrent first accesses wait for a single call of the function.
+// A Lazy must not be copied after first use.
+type Lazy[T any] struct {
+	_ noCopy
+
+	done  atomic.Bool
+	mu    sync.Mutex
+	f     func() Option[T]
+	opts  LazyOptions
+	value Option[T]
+}
+
+// NewLazy returns a Lazy that computes its Option with f.
+//
+//	token := optionalv2.NewLazy(func() optionalv2.Option[string] {
+//	    return optionalv2.FromNillable(fetchToken())
+//	})
+func NewLazy[T any](f func() Option[T]) *Lazy[T] {
+	return NewLazyWith(LazyOptions{}, f)
+}
+
+// NewLazyWith is like NewLazy, with options.
+func NewLazyWith[T any](opts LazyOptions, f func() Option[T]) *Lazy[T] {
+	return &Lazy[T]{f: f, opts: opts}
+}
+
+// Get returns the Option, calling the function if it hasn't been computed yet.
+// If the function panics, the panic is propagated and the function is called again on the next access.
+func (l *Lazy[T]) Get() Option[T] {
+	if l.done.Load() {
+		return l.value
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// another goroutine may have computed the value while we were waiting for the lock
+	if l.done.Load() {
+		return l.value
+	}
+	value := l.f()
+	if value.IsNone() && l.opts.RetryNone {
+		return value
+	}
+	l.value = value
+	l.f = nil
+	l.done.Store(true)
+	return value
+}
+
+// IsSome returns whether the computed Option has a value or not (see Option.IsSome).
+func (l *Lazy[T]) IsSome() bool {
+	return l.Get().IsSome()
+}
+
+// IsNone returns whether the computed Option doesn't have a value or not (see Option.IsNone).
+func (l *Lazy[T]) IsNone() bool {
+	return l.Get().IsNone()
+}
+
+// Unwrap returns the value of the computed Option regardless of its status (see Option.Unwrap).
+func (l *Lazy[T]) Unwrap() T {
+	return l.Get().Unwrap()
+}
+
+// Take returns the value of the computed Option, or ErrNoneValueTaken if it is None (see Option.Take).
+func (l *Lazy[T]) Take() (T, error) {
+	return l.Get().Take()
+}
+
+// TakeOr returns the value of the computed Option, or fallbackValue if it is None (see Option.TakeOr).
+func (l *Lazy[T]) TakeOr(fallbackValue T) T {
+	return l.Get().TakeOr(fallbackValue)
+}
+
+// String returns the string representation of the computed Option.
+func (l *Lazy[T]) String() string {
+	return l.Get().String()
+}
+
+// MarshalJSON implements the json.Marshaler interface for Lazy, marshalling the computed Option.
+// Unlike an Option field, a Lazy field is never omitted by `omitempty` (a non-nil pointer isn't empty), so a None Lazy
+// marshals like a None Option outside of omitempty, i.e. as the zero value of T. Only a nil *Lazy is omitted, so use
+// Get to copy the Option into the struct being marshalled when None must be left out.
+func (l *Lazy[T]) MarshalJSON() ([]byte, error) {
+	return l.Get().MarshalJSON()
+}
@@ -0,0 +1,160 @@
+package optionalv2_test
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+func TestLazy(t *testing.T) {
+	// Test the function is called once, on first access
+	t.Run("ComputedOnce", func(t *testing.T) {
+		calls := 0
+		l := optionalv2.NewLazy(func() optionalv2.Option[int] {
+			calls++
+			return optionalv2.Some(42)
+		})
+		assert.Equal(t, 0, calls)
+		assert.True(t, l.IsSome())
+		assert.False(t, l.IsNone())
+		assert.Equal(t, 42, l.Unwrap())
+		assert.Equal(t, 42, l.TakeOr(7))
+		v, err := l.Take()
+		assert.NoError(t, err)
+		assert.Equal(t, 42, v)
+		assert.Equal(t, "Some[42]", l.String())
+		assert.Equal(t, 1, calls)
+	})
+
+	// Test None is kept by default
+	t.Run("NoneKept", func(t *testing.T) {
+		calls := 0
+		l := optionalv2.NewLazy(func() optionalv2.Option[int] {
+			calls++
+			return optionalv2.None[int]()
+		})
+		assert.True(t, l.IsNone())
+		assert.Equal(t, 7, l.TakeOr(7))
+		_, err := l.Take()
+		assert.ErrorIs(t, err, optionalv2.ErrNoneValueTaken)
+		assert.Equal(t, 1, calls)
+	})
+
+	// Test RetryNone calls the function again until it isn't None
+	t.Run("RetryNone", func(t *testing.T) {
+		calls := 0
+		l := optionalv2.NewLazyWith(optionalv2.LazyOptions{RetryNone: true}, func() optionalv2.Option[string] {
+			calls++
+			if calls < 3 {
+				return optionalv2.None[string]()
+			}
+			return optionalv2.Some("")
+		})
+		assert.True(t, l.IsNone())
+		assert.True(t, l.IsNone())
+		assert.Equal(t, optionalv2.StateNull, l.Get().State())
+		assert.Equal(t, optionalv2.StateNull, l.Get().State())
+		assert.Equal(t, 3, calls)
+	})
+
+	// Test a panic is propagated and the function is called again
+	t.Run("Panic", func(t *testing.T) {
+		calls := 0
+		l := optionalv2.NewLazy(func() optionalv2.Option[int] {
+			calls++
+			if calls == 1 {
+				panic("boom")
+			}
+			return optionalv2.Some(1)
+		})
+		assert.PanicsWithValue(t, "boom", func() { l.Get() })
+		assert.Equal(t, 1, l.Unwrap())
+		assert.Equal(t, 2, calls)
+	})
+
+	// Test JSON marshalling of the three states
+	t.Run("MarshalJSON", func(t *testing.T) {
+		type response struct {
+			Name  *optionalv2.Lazy[string] `json:"name"`
+			Age   *optionalv2.Lazy[int]    `json:"age"`
+			Email *optionalv2.Lazy[string] `json:"email,omitempty"`
+		}
+		data, err := json.Marshal(response{
+			Name: optionalv2.NewLazy(func() optionalv2.Option[string] { return optionalv2.Some("Alice") }),
+			Age:  optionalv2.NewLazy(func() optionalv2.Option[int] { return optionalv2.Some(0) }),
+		})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":"Alice","age":null}`, string(data))
+	})
+
+	// Test a None Lazy isn't omitted, and marshals as the zero value
+	t.Run("MarshalJSONNone", func(t *testing.T) {
+		type response struct {
+			Count *optionalv2.Lazy[int]     `json:"count,omitempty"`
+			Name  optionalv2.Option[string] `json:"name,omitempty"`
+		}
+		none := optionalv2.NewLazy(optionalv2.None[int])
+		data, err := json.Marshal(response{Count: none})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"count":0}`, string(data))
+
+		// a nil Lazy is omitted
+		data, err = json.Marshal(response{})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{}`, string(data))
+	})
+
+	// Test concurrent first accesses share a single call
+	t.Run("ConcurrentFirstAccess", func(t *testing.T) {
+		var calls atomic.Int64
+		start := make(chan struct{})
+		l := optionalv2.NewLazy(func() optionalv2.Option[int] {
+			calls.Add(1)
+			return optionalv2.Some(42)
+		})
+
+		var wg sync.WaitGroup
+		for g := 0; g < 32; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				assert.Equal(t, 42, l.Unwrap())
+			}()
+		}
+		close(start)
+		wg.Wait()
+		assert.Equal(t, int64(1), calls.Load())
+	})
+
+	// Test concurrent accesses with RetryNone stop calling the function once it isn't None
+	t.Run("ConcurrentRetryNone", func(t *testing.T) {
+		var calls atomic.Int64
+		start := make(chan struct{})
+		l := optionalv2.NewLazyWith(optionalv2.LazyOptions{RetryNone: true}, func() optionalv2.Option[int] {
+			if calls.Add(1) < 5 {
+				return optionalv2.None[int]()
+			}
+			return optionalv2.Some(5)
+		})
+
+		var wg sync.WaitGroup
+		for g := 0; g < 32; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				for l.IsNone() {
+				}
+				assert.Equal(t, 5, l.Unwrap())
+			}()
+		}
+		close(start)
+		wg.Wait()
+		assert.Equal(t, int64(5), calls.Load())
+	})
+}
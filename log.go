@@ -0,0 +1,110 @@
+package optionalv2
+
+import (
+	"context"
+	"log/slog"
+)
+
+// noneLogValue is logged for None Options, so that DropNoneAttr and the handler returned by NewDropNoneHandler can
+// recognize and drop them.
+type noneLogValue struct{}
+
+// MarshalText prints NonePlaceholder when a None Option reaches a handler that doesn't drop it.
+func (noneLogValue) MarshalText() ([]byte, error) {
+	return []byte(NonePlaceholder), nil
+}
+
+// LogValue implements the slog.LogValuer interface for Option.
+// Some logs as the value (which is resolved in turn if it implements slog.LogValuer), and null logs as nil,
+// which handlers print as `null` (JSON) or `<nil>` (text).
+// None logs as a marker that DropNoneAttr and NewDropNoneHandler remove from the record; other handlers print it
+// as NonePlaceholder.
+func (o Option[T]) LogValue() slog.Value {
+	switch {
+	case o.IsNone():
+		return slog.AnyValue(noneLogValue{})
+	case o.isNull():
+		return slog.AnyValue(nil)
+	default:
+		return slog.AnyValue(o[true])
+	}
+}
+
+// DropNoneAttr removes the attributes holding None Options.
+// It can be used as the ReplaceAttr function of slog.HandlerOptions:
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: optionalv2.DropNoneAttr}))
+func DropNoneAttr(_ []string, a slog.Attr) slog.Attr {
+	if isNoneLogValue(a.Value.Resolve()) {
+		return slog.Attr{}
+	}
+	return a
+}
+
+// NewDropNoneHandler returns a slog.Handler that removes the attributes holding None Options, including inside
+// groups, before passing records to next.
+func NewDropNoneHandler(next slog.Handler) slog.Handler {
+	return &dropNoneHandler{next: next}
+}
+
+type dropNoneHandler struct {
+	next slog.Handler
+}
+
+func (h *dropNoneHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *dropNoneHandler) Handle(ctx context.Context, r slog.Record) error {
+	filtered := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if a, ok := dropNoneAttrs(a); ok {
+			filtered.AddAttrs(a)
+		}
+		return true
+	})
+	return h.next.Handle(ctx, filtered)
+}
+
+func (h *dropNoneHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	filtered := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if a, ok := dropNoneAttrs(a); ok {
+			filtered = append(filtered, a)
+		}
+	}
+	return &dropNoneHandler{next: h.next.WithAttrs(filtered)}
+}
+
+func (h *dropNoneHandler) WithGroup(name string) slog.Handler {
+	return &dropNoneHandler{next: h.next.WithGroup(name)}
+}
+
+// dropNoneAttrs resolves a and removes the None Options from it and, for groups, from its members.
+// It returns false if a itself must be dropped.
+func dropNoneAttrs(a slog.Attr) (slog.Attr, bool) {
+	a.Value = a.Value.Resolve()
+	if isNoneLogValue(a.Value) {
+		return slog.Attr{}, false
+	}
+	if a.Value.Kind() != slog.KindGroup {
+		return a, true
+	}
+
+	members := a.Value.Group()
+	filtered := make([]slog.Attr, 0, len(members))
+	for _, member := range members {
+		if member, ok := dropNoneAttrs(member); ok {
+			filtered = append(filtered, member)
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: slog.GroupValue(filtered...)}, true
+}
+
+func isNoneLogValue(v slog.Value) bool {
+	if v.Kind() != slog.KindAny {
+		return false
+	}
+	_, ok := v.Any().(noneLogValue)
+	return ok
+}
@@ -0,0 +1,77 @@
+package optionalv2_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+// secret implements slog.LogValuer to redact its value
+type secret string
+
+func (secret) LogValue() slog.Value {
+	return slog.StringValue("REDACTED")
+}
+
+func removeTime(_ []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+	return a
+}
+
+func TestLog(t *testing.T) {
+	// Test LogValue for the three states
+	t.Run("LogValue", func(t *testing.T) {
+		assert.Equal(t, int64(42), optionalv2.Some(42).LogValue().Resolve().Int64())
+		assert.Nil(t, optionalv2.Some(0).LogValue().Any())
+		assert.Equal(t, "REDACTED", optionalv2.Some(secret("hunter2")).LogValue().Resolve().String())
+		assert.Equal(t, slog.KindAny, optionalv2.None[int]().LogValue().Kind())
+	})
+
+	// Test None is printed as a placeholder by plain handlers
+	t.Run("PlainHandler", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeTime}))
+		logger.Info("user", "name", optionalv2.Some("Alice"), "age", optionalv2.Some(0), "email", optionalv2.None[string]())
+		assert.Equal(t, "level=INFO msg=user name=Alice age=<nil> email=<none>\n", buf.String())
+	})
+
+	// Test DropNoneAttr as a ReplaceAttr function
+	t.Run("DropNoneAttr", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: optionalv2.DropNoneAttr}))
+		logger.Info("user",
+			"name", optionalv2.Some("Alice"),
+			"age", optionalv2.Some(0),
+			"email", optionalv2.None[string](),
+			slog.Group("auth", "token", optionalv2.Some(secret("t")), "expires", optionalv2.None[int]()),
+		)
+
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, "Alice", record["name"])
+		assert.Contains(t, record, "age")
+		assert.Nil(t, record["age"])
+		assert.NotContains(t, record, "email")
+		assert.Equal(t, map[string]any{"token": "REDACTED"}, record["auth"])
+	})
+
+	// Test the handler wrapper drops None across attributes, groups and WithAttrs
+	t.Run("DropNoneHandler", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(optionalv2.NewDropNoneHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeTime})))
+		logger = logger.With("tenant", optionalv2.None[string](), "region", optionalv2.Some("eu"))
+		logger.WithGroup("req").Info("done",
+			"status", optionalv2.Some(200),
+			"error", optionalv2.None[string](),
+			slog.Group("user", "id", optionalv2.Some(7), "name", optionalv2.None[string]()),
+			slog.Group("empty", "x", optionalv2.None[int]()),
+		)
+		assert.JSONEq(t, `{"level":"INFO","msg":"done","region":"eu","req":{"status":200,"user":{"id":7}}}`, buf.String())
+	})
+}
@@ -0,0 +1,264 @@
+package optionalv2
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrFlattenConflict represents the error that is raised when a flattened map has a key that is both a value and the
+// prefix of other keys (e.g. `address` and `address.city`).
+var ErrFlattenConflict = errors.New("conflicting flattened keys")
+
+// MapOptions configures ToMapWith and FromMapWith.
+type MapOptions struct {
+	// Flatten stores nested struct fields under dotted keys (e.g. `address.city`) instead of nested maps.
+	Flatten bool
+}
+
+// ToMap converts the struct src (or pointed to by src) into a map, e.g. for an event bus or a document store.
+// See ToMapWith for details.
+func ToMap(src any) (map[string]any, error) {
+	return ToMapWith(MapOptions{}, src)
+}
+
+// ToMapWith converts the struct src (or pointed to by src) into a map.
+//
+// Keys are named after the `json` tag of the fields, and embedded structs and pointers to structs are flattened.
+// None fields are omitted, null fields are stored as nil, and Some fields hold their value. Nested structs (directly,
+// through a pointer, or held by a Some Option) become nested maps, or dotted keys with Flatten, except for the types
+// that marshal themselves (e.g. time.Time), which are stored as is. A nested struct without any field to store is an
+// empty map, also with Flatten. Slices and arrays of Options or structs become []any of converted elements. Other
+// fields hold their value, unless they are empty and tagged `omitempty`.
+func ToMapWith(opts MapOptions, src any) (map[string]any, error) {
+	v := reflect.ValueOf(src)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	m := structToMap(v)
+	if opts.Flatten {
+		flat := map[string]any{}
+		flattenMap(flat, "", m)
+		return flat, nil
+	}
+	return m, nil
+}
+
+// FromMap decodes the map m into the struct pointed to by dst. See FromMapWith for details.
+func FromMap(m map[string]any, dst any) error {
+	return FromMapWith(MapOptions{}, m, dst)
+}
+
+// FromMapWith decodes the map m into the struct pointed to by dst, inverting ToMapWith.
+//
+// Option fields become None when their key is absent, null when its value is nil, and Some otherwise. Other fields
+// are left unchanged when their key is absent. Nested maps are decoded recursively, and values that can't be assigned
+// to their field (e.g. a float64 for an int field) are converted through JSON. All errors are reported together.
+func FromMapWith(opts MapOptions, m map[string]any, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	if opts.Flatten {
+		nested, err := unflattenMap(m)
+		if err != nil {
+			return err
+		}
+		m = nested
+	}
+	return mapToStruct(m, v.Elem(), "")
+}
+
+func structToMap(v reflect.Value) map[string]any {
+	m := map[string]any{}
+	for _, f := range jsonFields(v.Type()) {
+		fv, ok := f.Value(v)
+		if !ok {
+			// if field is promoted through a nil embedded pointer, omit it like encoding/json
+			continue
+		}
+		if IsOptionType(f.Type) {
+			if state, _ := ReflectGet(fv); state == StateNone {
+				// if field is unspecified, omit it
+				continue
+			}
+		} else if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		m[f.Name] = mapValue(fv)
+	}
+	return m
+}
+
+func mapValue(v reflect.Value) any {
+	t := v.Type()
+	switch {
+	case IsOptionType(t):
+		state, inner := ReflectGet(v)
+		if state != StateSome {
+			return nil
+		}
+		return mapValue(inner)
+	case isNestedStruct(t):
+		return structToMap(v)
+	case t.Kind() == reflect.Pointer && isNestedStruct(t.Elem()):
+		if v.IsNil() {
+			return nil
+		}
+		return structToMap(v.Elem())
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && convertsElems(t.Elem()):
+		if t.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = mapValue(v.Index(i))
+		}
+		return items
+	default:
+		return v.Interface()
+	}
+}
+
+// convertsElems reports whether mapValue converts the elements of type elem of a slice or an array, so that the map
+// holds no Options or structs, even inside lists.
+func convertsElems(elem reflect.Type) bool {
+	return IsOptionType(elem) || isNestedStruct(elem) || (elem.Kind() == reflect.Pointer && isNestedStruct(elem.Elem()))
+}
+
+func mapToStruct(m map[string]any, v reflect.Value, prefix string) error {
+	var errs []error
+	for _, f := range jsonFields(v.Type()) {
+		raw, ok := m[f.Name]
+		if !ok {
+			if fv, ok := f.Value(v); ok && IsOptionType(f.Type) {
+				ReflectSet(fv, StateNone, reflect.Value{})
+			}
+			continue
+		}
+		fv, err := f.Alloc(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("decode %q: %w", prefix+f.Name, err))
+			continue
+		}
+		if err := setMapValue(fv, raw, prefix+f.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func setMapValue(field reflect.Value, raw any, path string) error {
+	t := field.Type()
+	// if value is `null`
+	if raw == nil {
+		if IsOptionType(t) {
+			ReflectSet(field, StateNull, reflect.Value{})
+		} else {
+			field.Set(reflect.Zero(t))
+		}
+		return nil
+	}
+
+	// if value is a nested struct, decode it field by field
+	nested, isMap := raw.(map[string]any)
+	switch {
+	case IsOptionType(t) && isMap && isNestedStruct(ReflectValueType(t)):
+		inner := reflect.New(ReflectValueType(t)).Elem()
+		if err := mapToStruct(nested, inner, path+"."); err != nil {
+			return err
+		}
+		ReflectSet(field, StateSome, inner)
+		return nil
+	case isMap && isNestedStruct(t):
+		return mapToStruct(nested, field, path+".")
+	case isMap && t.Kind() == reflect.Pointer && isNestedStruct(t.Elem()):
+		if field.IsNil() {
+			field.Set(reflect.New(t.Elem()))
+		}
+		return mapToStruct(nested, field.Elem(), path+".")
+	}
+
+	// otherwise, we have an actual value, so convert it
+	target := t
+	if IsOptionType(t) {
+		target = ReflectValueType(t)
+	}
+	converted, err := convertValue(raw, target)
+	if err != nil {
+		return fmt.Errorf("decode %q: %w", path, err)
+	}
+	if IsOptionType(t) {
+		ReflectSet(field, StateSome, converted)
+	} else {
+		field.Set(converted)
+	}
+	return nil
+}
+
+// flattenMap stores the values of m into flat under dotted keys.
+// An empty nested map is kept as is under its key, so that the struct it stands for isn't lost.
+func flattenMap(flat map[string]any, prefix string, m map[string]any) {
+	for k, v := range m {
+		if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+			flattenMap(flat, prefix+k+".", nested)
+			continue
+		}
+		flat[prefix+k] = v
+	}
+}
+
+// unflattenMap turns the dotted keys of flat into nested maps.
+func unflattenMap(flat map[string]any) (map[string]any, error) {
+	m := map[string]any{}
+	for k, v := range flat {
+		segments := strings.Split(k, ".")
+		current := m
+		for _, segment := range segments[:len(segments)-1] {
+			next, ok := current[segment]
+			if !ok {
+				child := map[string]any{}
+				current[segment] = child
+				current = child
+				continue
+			}
+			child, ok := next.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%w: %q", ErrFlattenConflict, k)
+			}
+			current = child
+		}
+		last := segments[len(segments)-1]
+		if existing, ok := current[last]; ok {
+			// an empty nested map doesn't conflict with the dotted keys of the same struct
+			_, existingIsMap := existing.(map[string]any)
+			if nested, isMap := v.(map[string]any); existingIsMap && isMap && len(nested) == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("%w: %q", ErrFlattenConflict, k)
+		}
+		current[last] = v
+	}
+	return m, nil
+}
+
+// isEmptyValue reports whether v is empty for `omitempty`, like encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	default:
+		return false
+	}
+}
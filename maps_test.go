@@ -0,0 +1,257 @@
+package optionalv2_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+type mapGeo struct {
+	Lat optionalv2.Option[float64] `json:"lat"`
+	Lng optionalv2.Option[float64] `json:"lng"`
+}
+
+type mapAddress struct {
+	City optionalv2.Option[string] `json:"city"`
+	Geo  *mapGeo                   `json:"geo"`
+}
+
+type MapAudit struct {
+	UpdatedBy optionalv2.Option[string] `json:"updatedBy"`
+}
+
+type mapEvent struct {
+	MapAudit
+	ID        string                        `json:"id"`
+	Name      optionalv2.Option[string]     `json:"name"`
+	Email     optionalv2.Option[string]     `json:"email"`
+	Age       optionalv2.Option[int]        `json:"age"`
+	At        time.Time                     `json:"at"`
+	Address   mapAddress                    `json:"address"`
+	Billing   optionalv2.Option[mapAddress] `json:"billing"`
+	Note      string                        `json:"note,omitempty"`
+	Internal  string                        `json:"-"`
+	Untouched string                        `json:"untouched"`
+}
+
+type mapItem struct {
+	SKU optionalv2.Option[string] `json:"sku"`
+	Qty optionalv2.Option[int]    `json:"qty"`
+}
+
+type mapOrder struct {
+	Items  []mapItem                `json:"items"`
+	Scores []optionalv2.Option[int] `json:"scores"`
+	Geos   [2]*mapGeo               `json:"geos"`
+	Tags   []string                 `json:"tags"`
+}
+
+type mapVersioned struct {
+	*MapAudit
+	ID string `json:"id"`
+}
+
+func TestToMap(t *testing.T) {
+	at := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	event := mapEvent{
+		MapAudit: MapAudit{UpdatedBy: optionalv2.Some("admin")},
+		ID:       "e1",
+		Name:     optionalv2.Some("Alice"),
+		Age:      optionalv2.Some(0),
+		At:       at,
+		Address:  mapAddress{City: optionalv2.Some("Paris"), Geo: &mapGeo{Lat: optionalv2.Some(48.8)}},
+		Billing:  optionalv2.Some(mapAddress{City: optionalv2.Some("")}),
+		Internal: "secret",
+	}
+
+	// Test None is omitted, null is nil, and nested structs become nested maps
+	t.Run("Nested", func(t *testing.T) {
+		m, err := optionalv2.ToMap(&event)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"updatedBy": "admin",
+			"id":        "e1",
+			"name":      "Alice",
+			"age":       nil,
+			"at":        at,
+			"address": map[string]any{
+				"city": "Paris",
+				"geo":  map[string]any{"lat": 48.8},
+			},
+			"billing":   map[string]any{"city": nil, "geo": nil},
+			"untouched": "",
+		}, m)
+	})
+
+	// Test Flatten uses dotted keys
+	t.Run("Flatten", func(t *testing.T) {
+		m, err := optionalv2.ToMapWith(optionalv2.MapOptions{Flatten: true}, event)
+		assert.NoError(t, err)
+		assert.Equal(t, "Paris", m["address.city"])
+		assert.Equal(t, 48.8, m["address.geo.lat"])
+		assert.Contains(t, m, "billing.city")
+		assert.Nil(t, m["billing.city"])
+		assert.NotContains(t, m, "address")
+		assert.NotContains(t, m, "address.geo.lng")
+	})
+
+	// Test the elements of lists are converted, so that the map holds no Options
+	t.Run("Lists", func(t *testing.T) {
+		m, err := optionalv2.ToMap(mapOrder{
+			Items:  []mapItem{{SKU: optionalv2.Some("a")}},
+			Scores: []optionalv2.Option[int]{optionalv2.Some(1), optionalv2.Some(0), optionalv2.None[int]()},
+			Geos:   [2]*mapGeo{{Lat: optionalv2.Some(1.5)}},
+			Tags:   []string{"x"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"items":  []any{map[string]any{"sku": "a"}},
+			"scores": []any{1, nil, nil},
+			"geos":   []any{map[string]any{"lat": 1.5}, nil},
+			"tags":   []string{"x"},
+		}, m)
+
+		m, err = optionalv2.ToMap(mapOrder{})
+		assert.NoError(t, err)
+		assert.Nil(t, m["items"])
+	})
+
+	// Test the fields of an embedded pointer are flattened like encoding/json, and omitted when it is nil
+	t.Run("EmbeddedPointer", func(t *testing.T) {
+		m, err := optionalv2.ToMap(mapVersioned{MapAudit: &MapAudit{UpdatedBy: optionalv2.Some("admin")}, ID: "e1"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"updatedBy": "admin", "id": "e1"}, m)
+
+		m, err = optionalv2.ToMap(mapVersioned{ID: "e1"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"id": "e1"}, m)
+	})
+
+	// Test non-struct sources are rejected
+	t.Run("NotStruct", func(t *testing.T) {
+		_, err := optionalv2.ToMap(map[string]any{})
+		assert.ErrorIs(t, err, optionalv2.ErrNotStruct)
+	})
+}
+
+func TestFromMap(t *testing.T) {
+	// Test absent keys are None, nil is null, and nested maps are decoded recursively
+	t.Run("Nested", func(t *testing.T) {
+		m := map[string]any{
+			"updatedBy": nil,
+			"id":        "e1",
+			"name":      "Alice",
+			"age":       float64(30),
+			"at":        "2024-01-02T00:00:00Z",
+			"address": map[string]any{
+				"geo": map[string]any{"lat": 48.8, "lng": nil},
+			},
+			"billing": map[string]any{"city": "Lyon"},
+		}
+		event := mapEvent{Untouched: "kept", Email: optionalv2.Some("old")}
+		assert.NoError(t, optionalv2.FromMap(m, &event))
+		assert.Equal(t, optionalv2.StateNull, event.UpdatedBy.State())
+		assert.Equal(t, "e1", event.ID)
+		assert.Equal(t, optionalv2.Some("Alice"), event.Name)
+		assert.True(t, event.Email.IsNone())
+		assert.Equal(t, optionalv2.Some(30), event.Age)
+		assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), event.At)
+		assert.True(t, event.Address.City.IsNone())
+		assert.Equal(t, optionalv2.Some(48.8), event.Address.Geo.Lat)
+		assert.Equal(t, optionalv2.StateNull, event.Address.Geo.Lng.State())
+		assert.Equal(t, optionalv2.Some("Lyon"), event.Billing.Unwrap().City)
+		assert.Nil(t, event.Billing.Unwrap().Geo)
+		assert.Equal(t, "kept", event.Untouched)
+	})
+
+	// Test a round trip through a map preserves absence, including with Flatten
+	t.Run("RoundTrip", func(t *testing.T) {
+		event := mapEvent{
+			ID:      "e1",
+			Name:    optionalv2.Some("Alice"),
+			Age:     optionalv2.Some(0),
+			Address: mapAddress{City: optionalv2.Some("Paris"), Geo: &mapGeo{Lng: optionalv2.Some(2.3)}},
+			Billing: optionalv2.Some(mapAddress{City: optionalv2.Some("Lyon")}),
+		}
+		for _, opts := range []optionalv2.MapOptions{{}, {Flatten: true}} {
+			m, err := optionalv2.ToMapWith(opts, event)
+			assert.NoError(t, err)
+			var decoded mapEvent
+			assert.NoError(t, optionalv2.FromMapWith(opts, m, &decoded))
+			assert.True(t, decoded.Email.IsNone())
+			assert.True(t, decoded.Address.Geo.Lat.IsNone())
+			assert.Equal(t, optionalv2.StateNull, decoded.Age.State())
+
+			again, err := optionalv2.ToMapWith(opts, decoded)
+			assert.NoError(t, err)
+			assert.Equal(t, m, again)
+		}
+	})
+
+	// Test lists produced by ToMap are decoded back
+	t.Run("Lists", func(t *testing.T) {
+		var order mapOrder
+		assert.NoError(t, optionalv2.FromMap(map[string]any{
+			"items":  []any{map[string]any{"sku": "a", "qty": nil}},
+			"scores": []any{1, nil},
+			"geos":   []any{map[string]any{"lat": 1.5}, nil},
+		}, &order))
+		assert.Equal(t, optionalv2.Some("a"), order.Items[0].SKU)
+		assert.Equal(t, optionalv2.StateNull, order.Items[0].Qty.State())
+		assert.Equal(t, optionalv2.Some(1), order.Scores[0])
+		assert.Equal(t, optionalv2.StateNull, order.Scores[1].State())
+		assert.Equal(t, optionalv2.Some(1.5), order.Geos[0].Lat)
+		assert.Nil(t, order.Geos[1])
+	})
+
+	// Test a struct whose fields are all empty survives Flatten as an empty map
+	t.Run("FlattenEmptyStruct", func(t *testing.T) {
+		event := mapEvent{Billing: optionalv2.Some(mapAddress{Geo: &mapGeo{}})}
+		m, err := optionalv2.ToMapWith(optionalv2.MapOptions{Flatten: true}, event)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{}, m["billing.geo"])
+
+		var decoded mapEvent
+		assert.NoError(t, optionalv2.FromMapWith(optionalv2.MapOptions{Flatten: true}, m, &decoded))
+		assert.Equal(t, optionalv2.StateSome, decoded.Billing.State())
+		assert.NotNil(t, decoded.Billing.Unwrap().Geo)
+
+		// an empty map doesn't conflict with the dotted keys of the same struct
+		assert.NoError(t, optionalv2.FromMapWith(optionalv2.MapOptions{Flatten: true}, map[string]any{
+			"billing":      map[string]any{},
+			"billing.city": "Lyon",
+		}, &decoded))
+		assert.Equal(t, optionalv2.Some("Lyon"), decoded.Billing.Unwrap().City)
+	})
+
+	// Test an embedded pointer is only allocated when one of its fields is present
+	t.Run("EmbeddedPointer", func(t *testing.T) {
+		var v mapVersioned
+		assert.NoError(t, optionalv2.FromMap(map[string]any{"id": "e1"}, &v))
+		assert.Nil(t, v.MapAudit)
+
+		assert.NoError(t, optionalv2.FromMap(map[string]any{"updatedBy": "admin"}, &v))
+		assert.Equal(t, optionalv2.Some("admin"), v.UpdatedBy)
+	})
+
+	// Test errors are reported with their path
+	t.Run("Errors", func(t *testing.T) {
+		var event mapEvent
+		err := optionalv2.FromMap(map[string]any{
+			"age":     "old",
+			"address": map[string]any{"city": 1},
+		}, &event)
+		assert.ErrorContains(t, err, `decode "age"`)
+		assert.ErrorContains(t, err, `decode "address.city"`)
+
+		err = optionalv2.FromMapWith(optionalv2.MapOptions{Flatten: true}, map[string]any{
+			"address":      nil,
+			"address.city": "Paris",
+		}, &event)
+		assert.ErrorIs(t, err, optionalv2.ErrFlattenConflict)
+
+		assert.ErrorIs(t, optionalv2.FromMap(nil, event), optionalv2.ErrNotStruct)
+	})
+}
@@ -0,0 +1,115 @@
+package optionalv2
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrNotStruct represents the error that is raised when a function that walks struct fields receives another kind of value.
+var ErrNotStruct = errors.New("value is not a struct")
+
+// Provenance maps the path of every merged field that isn't None (e.g. `DB.Host`) to the index of the layer that supplied it.
+type Provenance map[string]int
+
+// MergeOptions configures MergeWith.
+type MergeOptions struct {
+	// NullResets makes an explicit null reset the field to the value of the last (lowest priority) layer, which usually
+	// holds the defaults. By default, a null is a value like any other and overrides lower priority layers.
+	NullResets bool
+}
+
+// Merge merges layers of a struct of Option fields, in priority order (the first layer wins).
+// See MergeWith for details.
+//
+//	cfg, provenance, err := optionalv2.Merge(fromFlags, fromEnv, fromFile, defaults)
+func Merge[T any](layers ...T) (T, Provenance, error) {
+	return MergeWith(MergeOptions{}, layers...)
+}
+
+// MergeWith merges layers of a struct of Option fields, in priority order (the first layer wins).
+// Each Option field is resolved like Or, i.e. it takes the value of the first layer in which it isn't None.
+// Nested struct fields are merged recursively, and any other field, including structs like time.Time that don't expose
+// their fields, takes the value of the first layer in which it isn't the zero value. The returned Provenance records which layer supplied each Option field.
+func MergeWith[T any](opts MergeOptions, layers ...T) (T, Provenance, error) {
+	var result T
+	dst := reflect.ValueOf(&result).Elem()
+	if dst.Kind() != reflect.Struct {
+		return result, nil, ErrNotStruct
+	}
+
+	values := make([]reflect.Value, len(layers))
+	for i := range layers {
+		values[i] = reflect.ValueOf(&layers[i]).Elem()
+	}
+	provenance := Provenance{}
+	mergeStruct(dst, values, "", opts, provenance)
+	return result, provenance, nil
+}
+
+func mergeStruct(dst reflect.Value, layers []reflect.Value, prefix string, opts MergeOptions, provenance Provenance) {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+
+		fields := make([]reflect.Value, len(layers))
+		for j, layer := range layers {
+			fields[j] = layer.Field(i)
+		}
+
+		switch {
+		case IsOptionType(sf.Type):
+			mergeOptionField(dst.Field(i), fields, prefix+sf.Name, opts, provenance)
+		case isMergedStruct(sf.Type):
+			path := prefix + sf.Name + "."
+			if sf.Anonymous {
+				path = prefix
+			}
+			mergeStruct(dst.Field(i), fields, path, opts, provenance)
+		case sf.IsExported():
+			for _, field := range fields {
+				if !field.IsZero() {
+					dst.Field(i).Set(field)
+					break
+				}
+			}
+		}
+	}
+}
+
+// isMergedStruct returns whether the fields of the struct type t are merged one by one, i.e. whether it has exported
+// fields and doesn't marshal itself. Other structs, such as time.Time, are merged as a whole like any other field.
+func isMergedStruct(t reflect.Type) bool {
+	if !isNestedStruct(t) {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.IsExported() || (sf.Anonymous && isMergedStruct(sf.Type)) {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeOptionField(dst reflect.Value, fields []reflect.Value, path string, opts MergeOptions, provenance Provenance) {
+	last := len(fields) - 1
+	for i, field := range fields {
+		state, _ := ReflectGet(field)
+		if state == StateNone {
+			continue
+		}
+		if state == StateNull && opts.NullResets && i != last {
+			// reset to the defaults
+			i, field = last, fields[last]
+			if state, _ = ReflectGet(field); state == StateNone {
+				return
+			}
+		}
+		dst.Set(field)
+		provenance[path] = i
+		return
+	}
+}
@@ -0,0 +1,132 @@
+package optionalv2_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+type mergeDBConfig struct {
+	Host optionalv2.Option[string]
+	Port optionalv2.Option[int]
+}
+
+type mergeConfig struct {
+	Name    optionalv2.Option[string]
+	Port    optionalv2.Option[int]
+	Timeout optionalv2.Option[time.Duration]
+	Debug   optionalv2.Option[bool]
+	DB      mergeDBConfig
+	Version string
+}
+
+func TestMerge(t *testing.T) {
+	defaults := mergeConfig{
+		Name:    optionalv2.Some("service"),
+		Port:    optionalv2.Some(8080),
+		Timeout: optionalv2.Some(30 * time.Second),
+		DB:      mergeDBConfig{Host: optionalv2.Some("localhost"), Port: optionalv2.Some(5432)},
+		Version: "v1",
+	}
+	file := mergeConfig{
+		Port:    optionalv2.Some(9090),
+		Timeout: optionalv2.Some(10 * time.Second),
+		DB:      mergeDBConfig{Host: optionalv2.Some("db.internal")},
+	}
+	env := mergeConfig{
+		Timeout: optionalv2.Some(time.Duration(0)),
+		Version: "v2",
+	}
+	flags := mergeConfig{
+		Port:  optionalv2.Some(7070),
+		Debug: optionalv2.Some(true),
+	}
+
+	// Test fields are resolved with Or semantics, first layer first
+	t.Run("Merge", func(t *testing.T) {
+		cfg, provenance, err := optionalv2.Merge(flags, env, file, defaults)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "service", cfg.Name.Unwrap())
+		assert.Equal(t, 7070, cfg.Port.Unwrap())
+		assert.Equal(t, optionalv2.StateNull, cfg.Timeout.State())
+		assert.True(t, cfg.Debug.Unwrap())
+		assert.Equal(t, "db.internal", cfg.DB.Host.Unwrap())
+		assert.Equal(t, 5432, cfg.DB.Port.Unwrap())
+		assert.Equal(t, "v2", cfg.Version)
+
+		assert.Equal(t, optionalv2.Provenance{
+			"Name":    3,
+			"Port":    0,
+			"Timeout": 1,
+			"Debug":   0,
+			"DB.Host": 2,
+			"DB.Port": 3,
+		}, provenance)
+	})
+
+	// Test null resets a field to the last layer
+	t.Run("NullResets", func(t *testing.T) {
+		cfg, provenance, err := optionalv2.MergeWith(optionalv2.MergeOptions{NullResets: true}, flags, env, file, defaults)
+		assert.NoError(t, err)
+		assert.Equal(t, 30*time.Second, cfg.Timeout.Unwrap())
+		assert.Equal(t, 3, provenance["Timeout"])
+
+		// a null in the last layer is kept
+		cfg, provenance, err = optionalv2.MergeWith(optionalv2.MergeOptions{NullResets: true}, file, env)
+		assert.NoError(t, err)
+		assert.Equal(t, 10*time.Second, cfg.Timeout.Unwrap())
+		assert.Equal(t, 0, provenance["Timeout"])
+
+		// resetting to a None default leaves the field None
+		cfg, provenance, err = optionalv2.MergeWith(optionalv2.MergeOptions{NullResets: true}, env, flags)
+		assert.NoError(t, err)
+		assert.True(t, cfg.Timeout.IsNone())
+		assert.NotContains(t, provenance, "Timeout")
+	})
+
+	// Test embedded structs are flattened in the provenance
+	t.Run("Embedded", func(t *testing.T) {
+		type base struct {
+			ID optionalv2.Option[int]
+		}
+		type withBase struct {
+			base
+			Name optionalv2.Option[string]
+		}
+
+		cfg, provenance, err := optionalv2.Merge(
+			withBase{Name: optionalv2.Some("a")},
+			withBase{base: base{ID: optionalv2.Some(1)}, Name: optionalv2.Some("b")},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, cfg.ID.Unwrap())
+		assert.Equal(t, "a", cfg.Name.Unwrap())
+		assert.Equal(t, optionalv2.Provenance{"ID": 1, "Name": 0}, provenance)
+	})
+
+	// Test structs that don't expose their fields, like time.Time, are merged as a whole
+	t.Run("OpaqueStructs", func(t *testing.T) {
+		type withTime struct {
+			Name optionalv2.Option[string]
+			At   time.Time
+		}
+		cfg, _, err := optionalv2.Merge(withTime{Name: optionalv2.Some("a")}, withTime{At: time.Unix(5, 0)})
+		assert.NoError(t, err)
+		assert.Equal(t, "a", cfg.Name.Unwrap())
+		assert.True(t, time.Unix(5, 0).Equal(cfg.At))
+	})
+
+	// Test edge cases
+	t.Run("EdgeCases", func(t *testing.T) {
+		cfg, provenance, err := optionalv2.Merge[mergeConfig]()
+		assert.NoError(t, err)
+		assert.True(t, cfg.Name.IsNone())
+		assert.Empty(t, provenance)
+
+		_, _, err = optionalv2.Merge(1, 2)
+		assert.ErrorIs(t, err, optionalv2.ErrNotStruct)
+	})
+}
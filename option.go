@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"reflect"
 )
 
 var (
@@ -42,7 +41,7 @@ func (o Option[T]) isNull() bool {
 // Some is a function to make an Option type value with the actual value.
 func Some[T any](v T) Option[T] {
 	// Check if the value is the zero value of its type
-	if reflect.ValueOf(v).IsZero() {
+	if isNullValue(&v) {
 		return null[T]()
 	}
 
@@ -193,14 +192,17 @@ func (o Option[T]) IfNoneWithError(f func() error) error {
 	return f()
 }
 
-// String returns a string representation of the Option.
+// String returns a string representation of the Option, i.e. `Some[value]`, `Null[]` or `None[]`.
 // It includes the unwrapped value for Some, and if the value implements fmt.Stringer, it uses its custom string representation.
 func (o Option[T]) String() string {
 	if o.IsNone() {
 		return "None[]"
 	}
 
-	// Unwrap the value for both Some and Null
+	if o.isNull() {
+		return "Null[]"
+	}
+
 	v := o.Unwrap()
 
 	// Check if the value implements fmt.Stringer for custom string formatting
@@ -219,6 +221,11 @@ func (o Option[T]) MarshalJSON() ([]byte, error) {
 		return NullBytes, nil
 	}
 
+	// if the value reports itself as null (see NullChecker), marshal it as `null`
+	if v, ok := o[true]; ok && checksNull(&v) {
+		return NullBytes, nil
+	}
+
 	// if field was unspecified, and `omitempty` is set on the field's tags, `json.Marshal` will omit this field
 
 	// otherwise: we have a value, so marshal it
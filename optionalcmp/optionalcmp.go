@@ -0,0 +1,41 @@
+// Package optionalcmp provides github.com/google/go-cmp options for comparing Option values.
+//
+// Options are maps, so without these options cmp.Diff reports them as `map[bool]T`.
+// With Transformer, they are reported with their state:
+//
+//	if diff := cmp.Diff(want, got, optionalcmp.Transformer()); diff != "" {
+//		t.Errorf("mismatch (-want +got):\n%s", diff)
+//	}
+package optionalcmp
+
+import (
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+// View is the representation of an Option produced by Transformer.
+type View struct {
+	State optionalv2.State
+	Value any
+}
+
+// Transformer returns a cmp.Option that compares every Option type by its state and, for Some, its value.
+// Diffs show the Options as a View, e.g. `{State: Some, Value: 42}`.
+func Transformer() cmp.Option {
+	return cmp.FilterValues(func(x, y any) bool {
+		return x != nil && optionalv2.IsOptionType(reflect.TypeOf(x))
+	}, cmp.Transformer("Option", func(o any) View {
+		state, v := optionalv2.ReflectGet(reflect.ValueOf(o))
+		if state != optionalv2.StateSome {
+			return View{State: state}
+		}
+		return View{State: state, Value: v.Interface()}
+	}))
+}
+
+// Comparer returns a cmp.Option that compares Options of type Option[T] with optionalv2.Equal.
+func Comparer[T comparable]() cmp.Option {
+	return cmp.Comparer(optionalv2.Equal[T])
+}
@@ -0,0 +1,43 @@
+package optionalcmp_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/optionalcmp"
+)
+
+type user struct {
+	Name optionalv2.Option[string]
+	Age  optionalv2.Option[int]
+}
+
+func TestOptionalCmp(t *testing.T) {
+	// Test the Transformer compares and reports every Option type by state
+	t.Run("Transformer", func(t *testing.T) {
+		a := user{Name: optionalv2.Some("Alice"), Age: optionalv2.Some(0)}
+		b := user{Name: optionalv2.Some("Alice"), Age: optionalv2.Some(0)}
+		assert.True(t, cmp.Equal(a, b, optionalcmp.Transformer()))
+		assert.True(t, cmp.Equal(optionalv2.None[int](), optionalv2.Option[int](nil), optionalcmp.Transformer()))
+
+		b.Age = optionalv2.None[int]()
+		diff := cmp.Diff(a, b, optionalcmp.Transformer())
+		assert.Contains(t, diff, "Null")
+		assert.Contains(t, diff, "None")
+		assert.NotContains(t, diff, "map[bool]")
+
+		b = user{Name: optionalv2.Some("Bob"), Age: optionalv2.Some(0)}
+		diff = cmp.Diff(a, b, optionalcmp.Transformer())
+		assert.Contains(t, diff, `"Alice"`)
+		assert.Contains(t, diff, `"Bob"`)
+	})
+
+	// Test the Comparer for a single Option type
+	t.Run("Comparer", func(t *testing.T) {
+		opts := cmp.Options{optionalcmp.Comparer[string](), optionalcmp.Comparer[int]()}
+		assert.True(t, cmp.Equal(user{Name: optionalv2.Some("a")}, user{Name: optionalv2.Some("a"), Age: optionalv2.None[int]()}, opts))
+		assert.False(t, cmp.Equal(user{Age: optionalv2.Some(0)}, user{Age: optionalv2.None[int]()}, opts))
+	})
+}
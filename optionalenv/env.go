@@ -0,0 +1,143 @@
+// Package optionalenv loads configuration from environment variables into structs of Option fields.
+//
+// An unset variable loads as None, a variable set to an empty value (e.g. `FOO=`) loads as null,
+// and any other value is parsed and loads as Some, including zero values (e.g. `PORT=0`).
+//
+//	type Config struct {
+//		Port    optionalv2.Option[int]           `env:"PORT" envDefault:"8080"`
+//		Timeout optionalv2.Option[time.Duration] `env:"TIMEOUT"`
+//		Hosts   optionalv2.Option[[]string]      `env:"HOSTS"`
+//		DB      DBConfig                         `envPrefix:"DB_"`
+//	}
+//
+//	var cfg Config
+//	err := optionalenv.Load(&cfg)
+//
+// The following struct tags are supported:
+//   - `env:"NAME"` names the variable of an Option field. Option fields without it are left untouched.
+//   - `envDefault:"value"` is used when the variable is unset, like TakeOr.
+//   - `envSeparator:";"` splits slice values (the default separator is a comma).
+//   - `envPrefix:"PREFIX_"` on a struct field prefixes the variable names of its fields.
+package optionalenv
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/internal/textconv"
+)
+
+// ErrInvalidDestination represents the error that is raised when Load doesn't receive a pointer to a struct.
+var ErrInvalidDestination = errors.New("destination must be a non-nil pointer to a struct")
+
+// Loader loads environment variables into structs of Option fields.
+// The zero value reads the process environment.
+type Loader struct {
+	// Lookup returns the value of a variable and whether it is set. The default is os.LookupEnv.
+	Lookup func(key string) (string, bool)
+	// Prefix is prepended to every variable name.
+	Prefix string
+}
+
+// Load loads the process environment into the struct pointed to by dst.
+func Load(dst any) error {
+	return (&Loader{}).Load(dst)
+}
+
+// Load loads environment variables into the struct pointed to by dst.
+// All parse errors are reported together.
+func (l *Loader) Load(dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+
+	lookup := l.Lookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	return errors.Join(l.loadStruct(v.Elem(), l.Prefix, lookup)...)
+}
+
+func (l *Loader) loadStruct(v reflect.Value, prefix string, lookup func(string) (string, bool)) []error {
+	var errs []error
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		field := v.Field(i)
+
+		if optionalv2.IsOptionType(sf.Type) {
+			name, ok := sf.Tag.Lookup("env")
+			if !ok || name == "" || name == "-" {
+				continue
+			}
+			if err := loadField(field, prefix+name, sf.Tag, lookup); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		// walk nested structs, allocating nil struct pointers
+		if sf.Type.Kind() == reflect.Pointer && sf.Type.Elem().Kind() == reflect.Struct {
+			if field.IsNil() {
+				if !field.CanSet() {
+					continue
+				}
+				field.Set(reflect.New(sf.Type.Elem()))
+			}
+			field = field.Elem()
+		}
+		if field.Kind() == reflect.Struct && !textconv.Supports(field.Type()) {
+			errs = append(errs, l.loadStruct(field, prefix+sf.Tag.Get("envPrefix"), lookup)...)
+		}
+	}
+	return errs
+}
+
+func loadField(field reflect.Value, name string, tag reflect.StructTag, lookup func(string) (string, bool)) error {
+	raw, ok := lookup(name)
+	if !ok {
+		raw, ok = tag.Lookup("envDefault")
+	}
+
+	// if variable is unset, and has no default
+	if !ok {
+		optionalv2.ReflectSet(field, optionalv2.StateNone, reflect.Value{})
+		return nil
+	}
+
+	// if variable is set, and empty
+	if raw == "" {
+		optionalv2.ReflectSet(field, optionalv2.StateNull, reflect.Value{})
+		return nil
+	}
+
+	// otherwise, we have an actual value, so parse it
+	valueType := optionalv2.ReflectValueType(field.Type())
+	v := reflect.New(valueType).Elem()
+	if valueType.Kind() == reflect.Slice && valueType.Elem().Kind() != reflect.Uint8 {
+		separator := tag.Get("envSeparator")
+		if separator == "" {
+			separator = ","
+		}
+		parts := strings.Split(raw, separator)
+		v.Set(reflect.MakeSlice(valueType, len(parts), len(parts)))
+		for i, part := range parts {
+			if err := textconv.Parse(strings.TrimSpace(part), v.Index(i)); err != nil {
+				return fmt.Errorf("parse %s: %w", name, err)
+			}
+		}
+	} else if err := textconv.Parse(raw, v); err != nil {
+		return fmt.Errorf("parse %s: %w", name, err)
+	}
+	// keep zero values, so that `PORT=0` isn't mistaken for `PORT=`
+	optionalv2.ReflectSetValue(field, v)
+	return nil
+}
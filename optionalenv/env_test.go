@@ -0,0 +1,134 @@
+package optionalenv_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/optionalenv"
+)
+
+type dbConfig struct {
+	Host optionalv2.Option[string] `env:"HOST"`
+	Port optionalv2.Option[int]    `env:"PORT" envDefault:"5432"`
+}
+
+type config struct {
+	Port     optionalv2.Option[int]           `env:"PORT" envDefault:"8080"`
+	Debug    optionalv2.Option[bool]          `env:"DEBUG"`
+	Name     optionalv2.Option[string]        `env:"NAME"`
+	Timeout  optionalv2.Option[time.Duration] `env:"TIMEOUT"`
+	Endpoint optionalv2.Option[*url.URL]      `env:"ENDPOINT"`
+	Hosts    optionalv2.Option[[]string]      `env:"HOSTS"`
+	Weights  optionalv2.Option[[]float64]     `env:"WEIGHTS" envSeparator:";"`
+	Untagged optionalv2.Option[string]
+	DB       dbConfig  `envPrefix:"DB_"`
+	Cache    *dbConfig `envPrefix:"CACHE_"`
+}
+
+func lookupFrom(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func TestLoad(t *testing.T) {
+	// Test unset, empty and valued variables
+	t.Run("States", func(t *testing.T) {
+		l := optionalenv.Loader{Lookup: lookupFrom(map[string]string{
+			"DEBUG":    "true",
+			"NAME":     "",
+			"Untagged": "x",
+		})}
+
+		var cfg config
+		assert.NoError(t, l.Load(&cfg))
+		assert.Equal(t, optionalv2.StateSome, cfg.Debug.State())
+		assert.True(t, cfg.Debug.Unwrap())
+		assert.Equal(t, optionalv2.StateNull, cfg.Name.State())
+		assert.True(t, cfg.Timeout.IsNone())
+		assert.True(t, cfg.Hosts.IsNone())
+		assert.True(t, cfg.Untagged.IsNone())
+	})
+
+	// Test zero values are kept, so they can be told apart from empty values
+	t.Run("ZeroValues", func(t *testing.T) {
+		l := optionalenv.Loader{Lookup: lookupFrom(map[string]string{
+			"PORT":  "0",
+			"DEBUG": "false",
+			"NAME":  "",
+		})}
+
+		var cfg config
+		assert.NoError(t, l.Load(&cfg))
+		assert.Equal(t, optionalv2.StateSome, cfg.Port.State())
+		assert.Equal(t, 0, cfg.Port.Unwrap())
+		assert.Equal(t, optionalv2.StateSome, cfg.Debug.State())
+		assert.False(t, cfg.Debug.Unwrap())
+		assert.Equal(t, optionalv2.StateNull, cfg.Name.State())
+	})
+
+	// Test defaults apply only to unset variables
+	t.Run("Defaults", func(t *testing.T) {
+		var cfg config
+		l := optionalenv.Loader{Lookup: lookupFrom(map[string]string{})}
+		assert.NoError(t, l.Load(&cfg))
+		assert.Equal(t, 8080, cfg.Port.Unwrap())
+		assert.Equal(t, 5432, cfg.DB.Port.Unwrap())
+
+		cfg = config{}
+		l = optionalenv.Loader{Lookup: lookupFrom(map[string]string{"PORT": "", "DB_PORT": "6543"})}
+		assert.NoError(t, l.Load(&cfg))
+		assert.Equal(t, optionalv2.StateNull, cfg.Port.State())
+		assert.Equal(t, 6543, cfg.DB.Port.Unwrap())
+	})
+
+	// Test durations, URLs, slices and nested prefixed structs
+	t.Run("Types", func(t *testing.T) {
+		l := optionalenv.Loader{
+			Prefix: "APP_",
+			Lookup: lookupFrom(map[string]string{
+				"APP_TIMEOUT":    "30s",
+				"APP_ENDPOINT":   "https://api.example.com/v1",
+				"APP_HOSTS":      "a.example.com, b.example.com",
+				"APP_WEIGHTS":    "0.5;1.5",
+				"APP_DB_HOST":    "db.local",
+				"APP_CACHE_HOST": "cache.local",
+			}),
+		}
+
+		var cfg config
+		assert.NoError(t, l.Load(&cfg))
+		assert.Equal(t, 30*time.Second, cfg.Timeout.Unwrap())
+		assert.Equal(t, "api.example.com", cfg.Endpoint.Unwrap().Host)
+		assert.Equal(t, []string{"a.example.com", "b.example.com"}, cfg.Hosts.Unwrap())
+		assert.Equal(t, []float64{0.5, 1.5}, cfg.Weights.Unwrap())
+		assert.Equal(t, "db.local", cfg.DB.Host.Unwrap())
+		assert.Equal(t, "cache.local", cfg.Cache.Host.Unwrap())
+		assert.Equal(t, 5432, cfg.Cache.Port.Unwrap())
+	})
+
+	// Test that all parse errors are reported together
+	t.Run("Errors", func(t *testing.T) {
+		l := optionalenv.Loader{Lookup: lookupFrom(map[string]string{
+			"PORT":    "eighty",
+			"DEBUG":   "yes please",
+			"TIMEOUT": "10s",
+			"DB_PORT": "-",
+		})}
+
+		var cfg config
+		err := l.Load(&cfg)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "parse PORT")
+		assert.Contains(t, err.Error(), "parse DEBUG")
+		assert.Contains(t, err.Error(), "parse DB_PORT")
+		assert.Equal(t, 10*time.Second, cfg.Timeout.Unwrap())
+
+		assert.ErrorIs(t, l.Load(cfg), optionalenv.ErrInvalidDestination)
+	})
+
+}
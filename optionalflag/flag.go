@@ -0,0 +1,231 @@
+// Package optionalflag adapts Option values to the flag package, so a program can tell whether a flag was passed.
+//
+// A flag that isn't passed stays None, a flag passed with an empty value (e.g. `-name=`) becomes null,
+// and any other value is parsed and becomes Some, including zero values (e.g. `-port=0` or `-v=false`).
+//
+//	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+//	port := optionalflag.Int(fs, "port", "port to listen on")
+//	_ = fs.Parse(os.Args[1:])
+//
+//	if port.IsNone() {
+//		// -port was not passed
+//	}
+package optionalflag
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"time"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/internal/textconv"
+)
+
+// ErrInvalidDestination represents the error that is raised when Register doesn't receive a pointer to a struct.
+var ErrInvalidDestination = errors.New("destination must be a non-nil pointer to a struct")
+
+// Value adapts an *Option[T] to the flag.Value and flag.Getter interfaces.
+// T can be any type supported by the flag package, a time.Duration, a slice of those (each use of the flag appends an
+// element), or a type implementing encoding.TextUnmarshaler.
+type Value[T any] struct {
+	opt *optionalv2.Option[T]
+}
+
+// NewValue returns a Value that stores into o.
+func NewValue[T any](o *optionalv2.Option[T]) *Value[T] {
+	return &Value[T]{opt: o}
+}
+
+// String implements the flag.Value interface.
+func (v *Value[T]) String() string {
+	// the flag package calls String on a zero Value to detect default values
+	if v == nil || v.opt == nil {
+		return ""
+	}
+	return formatOption(reflect.ValueOf(v.opt).Elem())
+}
+
+// Set implements the flag.Value interface.
+func (v *Value[T]) Set(s string) error {
+	return setOption(reflect.ValueOf(v.opt).Elem(), s)
+}
+
+// Get implements the flag.Getter interface. It returns the Option[T].
+func (v *Value[T]) Get() any {
+	return *v.opt
+}
+
+// IsBoolFlag allows boolean flags to be passed without a value (e.g. `-verbose`).
+func (v *Value[T]) IsBoolFlag() bool {
+	return isBool(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// Var defines a flag with the specified name and usage that stores into o.
+func Var[T any](fs *flag.FlagSet, o *optionalv2.Option[T], name, usage string) {
+	fs.Var(NewValue(o), name, usage)
+}
+
+// String defines a string flag with the specified name and usage.
+func String(fs *flag.FlagSet, name, usage string) *optionalv2.Option[string] {
+	return define[string](fs, name, usage)
+}
+
+// Int defines an int flag with the specified name and usage.
+func Int(fs *flag.FlagSet, name, usage string) *optionalv2.Option[int] {
+	return define[int](fs, name, usage)
+}
+
+// Int64 defines an int64 flag with the specified name and usage.
+func Int64(fs *flag.FlagSet, name, usage string) *optionalv2.Option[int64] {
+	return define[int64](fs, name, usage)
+}
+
+// Uint defines a uint flag with the specified name and usage.
+func Uint(fs *flag.FlagSet, name, usage string) *optionalv2.Option[uint] {
+	return define[uint](fs, name, usage)
+}
+
+// Float64 defines a float64 flag with the specified name and usage.
+func Float64(fs *flag.FlagSet, name, usage string) *optionalv2.Option[float64] {
+	return define[float64](fs, name, usage)
+}
+
+// Bool defines a bool flag with the specified name and usage.
+func Bool(fs *flag.FlagSet, name, usage string) *optionalv2.Option[bool] {
+	return define[bool](fs, name, usage)
+}
+
+// Duration defines a time.Duration flag with the specified name and usage.
+func Duration(fs *flag.FlagSet, name, usage string) *optionalv2.Option[time.Duration] {
+	return define[time.Duration](fs, name, usage)
+}
+
+func define[T any](fs *flag.FlagSet, name, usage string) *optionalv2.Option[T] {
+	o := optionalv2.None[T]()
+	Var(fs, &o, name, usage)
+	return &o
+}
+
+// Register defines a flag for every Option field of the struct pointed to by dst that has a `flag:"name"` tag.
+// The usage message is taken from the `usage` tag.
+//
+//	type Options struct {
+//		Port    optionalv2.Option[int]    `flag:"port" usage:"port to listen on"`
+//		Verbose optionalv2.Option[bool]   `flag:"v" usage:"verbose output"`
+//	}
+func Register(fs *flag.FlagSet, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+
+	t := v.Elem().Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := sf.Tag.Get("flag")
+		if name == "" || name == "-" || !sf.IsExported() || !optionalv2.IsOptionType(sf.Type) {
+			continue
+		}
+		valueType := optionalv2.ReflectValueType(sf.Type)
+		if !supports(valueType) {
+			return fmt.Errorf("flag %q: unsupported type %s", name, valueType)
+		}
+		fs.Var(&fieldValue{field: v.Elem().Field(i)}, name, sf.Tag.Get("usage"))
+	}
+	return nil
+}
+
+// fieldValue adapts an Option struct field to the flag.Value and flag.Getter interfaces.
+type fieldValue struct {
+	field reflect.Value
+}
+
+func (v *fieldValue) String() string {
+	if v == nil || !v.field.IsValid() {
+		return ""
+	}
+	return formatOption(v.field)
+}
+
+func (v *fieldValue) Set(s string) error {
+	return setOption(v.field, s)
+}
+
+func (v *fieldValue) Get() any {
+	return v.field.Interface()
+}
+
+func (v *fieldValue) IsBoolFlag() bool {
+	return isBool(optionalv2.ReflectValueType(v.field.Type()))
+}
+
+// setOption parses s into the Option held by field.
+// For slices, the parsed element is appended to the current value.
+func setOption(field reflect.Value, s string) error {
+	// if flag is passed, and empty
+	if s == "" {
+		optionalv2.ReflectSet(field, optionalv2.StateNull, reflect.Value{})
+		return nil
+	}
+
+	// otherwise, we have an actual value, so parse it
+	state, current := optionalv2.ReflectGet(field)
+	valueType := current.Type()
+	if isSlice(valueType) {
+		elem := reflect.New(valueType.Elem()).Elem()
+		if err := textconv.Parse(s, elem); err != nil {
+			return err
+		}
+		if state != optionalv2.StateSome {
+			current = reflect.MakeSlice(valueType, 0, 1)
+		}
+		optionalv2.ReflectSetValue(field, reflect.Append(current, elem))
+		return nil
+	}
+
+	v := reflect.New(valueType).Elem()
+	if err := textconv.Parse(s, v); err != nil {
+		return err
+	}
+	optionalv2.ReflectSetValue(field, v)
+	return nil
+}
+
+// formatOption formats the Option held by field. None and null are formatted as an empty string.
+func formatOption(field reflect.Value) string {
+	state, v := optionalv2.ReflectGet(field)
+	if state != optionalv2.StateSome {
+		return ""
+	}
+
+	if !isSlice(v.Type()) {
+		s, _ := textconv.Format(v)
+		return s
+	}
+	s := "["
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			s += " "
+		}
+		elem, _ := textconv.Format(v.Index(i))
+		s += elem
+	}
+	return s + "]"
+}
+
+func isSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+func isBool(t reflect.Type) bool {
+	return t.Kind() == reflect.Bool
+}
+
+func supports(t reflect.Type) bool {
+	if isSlice(t) {
+		return textconv.Supports(t.Elem())
+	}
+	return textconv.Supports(t)
+}
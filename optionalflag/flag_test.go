@@ -0,0 +1,154 @@
+package optionalflag_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/optionalflag"
+)
+
+// level implements encoding.TextUnmarshaler and encoding.TextMarshaler
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "debug":
+		*l = 1
+	case "info":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+func (l level) MarshalText() ([]byte, error) {
+	return []byte(map[level]string{1: "debug", 2: "info"}[l]), nil
+}
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func TestFlag(t *testing.T) {
+	// Test unset flags stay None and passed flags become Some
+	t.Run("Constructors", func(t *testing.T) {
+		fs := newFlagSet()
+		name := optionalflag.String(fs, "name", "")
+		port := optionalflag.Int(fs, "port", "")
+		limit := optionalflag.Int64(fs, "limit", "")
+		workers := optionalflag.Uint(fs, "workers", "")
+		ratio := optionalflag.Float64(fs, "ratio", "")
+		verbose := optionalflag.Bool(fs, "v", "")
+		timeout := optionalflag.Duration(fs, "timeout", "")
+
+		assert.NoError(t, fs.Parse([]string{"-port", "8080", "-v", "-timeout=5s", "-name=", "-ratio", "0.5"}))
+		assert.Equal(t, optionalv2.StateNull, name.State())
+		assert.Equal(t, 8080, port.Unwrap())
+		assert.True(t, limit.IsNone())
+		assert.True(t, workers.IsNone())
+		assert.Equal(t, 0.5, ratio.Unwrap())
+		assert.True(t, verbose.Unwrap())
+		assert.Equal(t, 5*time.Second, timeout.Unwrap())
+	})
+
+	// Test zero values are kept, so they can be told apart from an empty value
+	t.Run("ZeroValues", func(t *testing.T) {
+		fs := newFlagSet()
+		port := optionalflag.Int(fs, "port", "")
+		verbose := optionalflag.Bool(fs, "v", "")
+		name := optionalflag.String(fs, "name", "")
+
+		assert.NoError(t, fs.Parse([]string{"-port=0", "-v=false", "-name="}))
+		assert.Equal(t, optionalv2.StateSome, port.State())
+		assert.Equal(t, 0, port.Unwrap())
+		assert.Equal(t, optionalv2.StateSome, verbose.State())
+		assert.False(t, verbose.Unwrap())
+		assert.Equal(t, optionalv2.StateNull, name.State())
+		assert.Equal(t, "false", fs.Lookup("v").Value.String())
+
+		type options struct {
+			Port    optionalv2.Option[int]  `flag:"port"`
+			Verbose optionalv2.Option[bool] `flag:"v"`
+		}
+		fs = newFlagSet()
+		var opts options
+		assert.NoError(t, optionalflag.Register(fs, &opts))
+		assert.NoError(t, fs.Parse([]string{"-port", "0", "-v=false"}))
+		assert.Equal(t, optionalv2.StateSome, opts.Port.State())
+		assert.Equal(t, optionalv2.StateSome, opts.Verbose.State())
+	})
+
+	// Test Value with Var, flag.Getter and TextUnmarshaler types
+	t.Run("Var", func(t *testing.T) {
+		fs := newFlagSet()
+		var lvl optionalv2.Option[level]
+		var tags optionalv2.Option[[]string]
+		optionalflag.Var(fs, &lvl, "level", "")
+		optionalflag.Var(fs, &tags, "tag", "")
+
+		assert.NoError(t, fs.Parse([]string{"-level", "info", "-tag", "a", "-tag", "b"}))
+		assert.Equal(t, level(2), lvl.Unwrap())
+		assert.Equal(t, []string{"a", "b"}, tags.Unwrap())
+
+		getter := fs.Lookup("level").Value.(flag.Getter)
+		assert.Equal(t, lvl, getter.Get())
+		assert.Equal(t, "info", getter.String())
+		assert.Equal(t, "[a b]", fs.Lookup("tag").Value.String())
+
+		fs = newFlagSet()
+		optionalflag.Var(fs, &lvl, "level", "")
+		assert.Error(t, fs.Parse([]string{"-level", "trace"}))
+	})
+
+	// Test registering a whole struct of Options
+	t.Run("Register", func(t *testing.T) {
+		type options struct {
+			Port    optionalv2.Option[int]           `flag:"port" usage:"port to listen on"`
+			Verbose optionalv2.Option[bool]          `flag:"v" usage:"verbose output"`
+			Level   optionalv2.Option[level]         `flag:"level"`
+			Timeout optionalv2.Option[time.Duration] `flag:"timeout"`
+			Hosts   optionalv2.Option[[]string]      `flag:"host"`
+			Skipped optionalv2.Option[string]
+		}
+
+		fs := newFlagSet()
+		var opts options
+		assert.NoError(t, optionalflag.Register(fs, &opts))
+		assert.Nil(t, fs.Lookup("Skipped"))
+		assert.Equal(t, "port to listen on", fs.Lookup("port").Usage)
+
+		assert.NoError(t, fs.Parse([]string{"-v", "-level=debug", "-host", "a", "-host", "b"}))
+		assert.True(t, opts.Port.IsNone())
+		assert.True(t, opts.Verbose.Unwrap())
+		assert.Equal(t, level(1), opts.Level.Unwrap())
+		assert.True(t, opts.Timeout.IsNone())
+		assert.Equal(t, []string{"a", "b"}, opts.Hosts.Unwrap())
+		assert.Equal(t, opts.Verbose, fs.Lookup("v").Value.(flag.Getter).Get())
+
+		// unset flags are printed without a default value
+		var out bytes.Buffer
+		fs.SetOutput(&out)
+		fs.PrintDefaults()
+		assert.NotContains(t, out.String(), "default")
+	})
+
+	// Test that invalid structs are rejected
+	t.Run("RegisterInvalid", func(t *testing.T) {
+		assert.ErrorIs(t, optionalflag.Register(newFlagSet(), struct{}{}), optionalflag.ErrInvalidDestination)
+
+		var unsupported struct {
+			Meta optionalv2.Option[map[string]int] `flag:"meta"`
+		}
+		assert.Error(t, optionalflag.Register(newFlagSet(), &unsupported))
+	})
+}
@@ -0,0 +1,167 @@
+// Package optionalform decodes URL query strings and forms into structs of Option fields, and encodes them back.
+//
+// A key that is absent decodes to None, a key that is present with an empty value (e.g. `?cursor=`) decodes to null
+// (configurable with EmptyPolicy), and a key with a value decodes to Some, including zero values (e.g. `?limit=0`).
+//
+//	type ListParams struct {
+//		Limit  optionalv2.Option[int]    `form:"limit"`
+//		Cursor optionalv2.Option[string] `form:"cursor"`
+//		Active optionalv2.Option[bool]   `form:"active"`
+//	}
+//
+//	var params ListParams
+//	err := optionalform.Decode(r.URL.Query(), &params)
+package optionalform
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+// EmptyPolicy controls how a key that is present with an empty value is decoded.
+type EmptyPolicy int
+
+const (
+	// EmptyAsNull decodes an empty value as null.
+	EmptyAsNull EmptyPolicy = iota
+	// EmptyAsNone decodes an empty value as None, as if the key was absent.
+	EmptyAsNone
+)
+
+// ErrInvalidDestination represents the error that is raised when Decode doesn't receive a pointer to a struct.
+var ErrInvalidDestination = errors.New("destination must be a non-nil pointer to a struct")
+
+var (
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// Decoder decodes url.Values into structs of Option fields.
+// The zero value is ready to use.
+type Decoder struct {
+	// Empty controls how a key that is present with an empty value is decoded. The default is EmptyAsNull.
+	Empty EmptyPolicy
+	// TimeLayout is the layout used to parse time.Time values. The default is time.RFC3339.
+	TimeLayout string
+}
+
+// Decode decodes values into the struct pointed to by dst using the default Decoder.
+func Decode(values url.Values, dst any) error {
+	return (&Decoder{}).Decode(values, dst)
+}
+
+// DecodeForm decodes a multipart form into the struct pointed to by dst using the default Decoder.
+func DecodeForm(form *multipart.Form, dst any) error {
+	return (&Decoder{}).DecodeForm(form, dst)
+}
+
+// Decode decodes values into the Option fields of the struct pointed to by dst.
+// Slice values are read from repeated keys (e.g. `?tag=a&tag=b`), and other values from the first value of the key.
+// All parse errors are reported together.
+func (d *Decoder) Decode(values url.Values, dst any) error {
+	return d.decode(values, nil, dst)
+}
+
+// DecodeForm decodes the values and files of a multipart form into the Option fields of the struct pointed to by dst.
+// Fields of type Option[*multipart.FileHeader] and Option[[]*multipart.FileHeader] are filled from the form files.
+func (d *Decoder) DecodeForm(form *multipart.Form, dst any) error {
+	if form == nil {
+		return d.decode(nil, nil, dst)
+	}
+	return d.decode(form.Value, form.File, dst)
+}
+
+func (d *Decoder) decode(values url.Values, files map[string][]*multipart.FileHeader, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+
+	var errs []error
+	for _, f := range optionFields(v.Elem().Type()) {
+		key := f.Name
+		field, ok := f.Value(v.Elem())
+		if !ok {
+			// if field is promoted through a nil embedded pointer, only allocate it for a value
+			if len(values[key]) == 0 && len(files[key]) == 0 {
+				continue
+			}
+			var err error
+			if field, err = f.Alloc(v.Elem()); err != nil {
+				errs = append(errs, fmt.Errorf("decode %q: %w", key, err))
+				continue
+			}
+		}
+
+		valueType := optionalv2.ReflectValueType(f.Type)
+		if valueType == fileHeaderType || valueType == fileHeaderSliceType {
+			d.decodeFiles(files[key], field, valueType)
+			continue
+		}
+		if err := d.decodeField(values[key], field, valueType); err != nil {
+			errs = append(errs, fmt.Errorf("decode %q: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Decoder) decodeField(raw []string, field reflect.Value, valueType reflect.Type) error {
+	// if key is absent
+	if len(raw) == 0 {
+		optionalv2.ReflectSet(field, optionalv2.StateNone, reflect.Value{})
+		return nil
+	}
+
+	// if key is present, and empty
+	if isEmpty(raw) {
+		if d.Empty == EmptyAsNone {
+			optionalv2.ReflectSet(field, optionalv2.StateNone, reflect.Value{})
+		} else {
+			optionalv2.ReflectSet(field, optionalv2.StateNull, reflect.Value{})
+		}
+		return nil
+	}
+
+	// otherwise, we have an actual value, so parse it
+	v := reflect.New(valueType).Elem()
+	if isSlice(valueType) {
+		v.Set(reflect.MakeSlice(valueType, len(raw), len(raw)))
+		for i, s := range raw {
+			if err := parseValue(s, v.Index(i), d.TimeLayout); err != nil {
+				return err
+			}
+		}
+	} else if err := parseValue(raw[0], v, d.TimeLayout); err != nil {
+		return err
+	}
+	// keep zero values, so that `limit=0` isn't mistaken for `limit=`
+	optionalv2.ReflectSetValue(field, v)
+	return nil
+}
+
+func (d *Decoder) decodeFiles(headers []*multipart.FileHeader, field reflect.Value, valueType reflect.Type) {
+	if len(headers) == 0 {
+		optionalv2.ReflectSet(field, optionalv2.StateNone, reflect.Value{})
+		return
+	}
+	if valueType == fileHeaderType {
+		optionalv2.ReflectSet(field, optionalv2.StateSome, reflect.ValueOf(headers[0]))
+		return
+	}
+	optionalv2.ReflectSet(field, optionalv2.StateSome, reflect.ValueOf(headers))
+}
+
+// isEmpty returns whether every value of a key is empty.
+func isEmpty(raw []string) bool {
+	for _, s := range raw {
+		if s != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package optionalform_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/optionalform"
+)
+
+type pagination struct {
+	Limit  optionalv2.Option[int]    `form:"limit"`
+	Cursor optionalv2.Option[string] `form:"cursor"`
+}
+
+type listParams struct {
+	pagination
+	Active  optionalv2.Option[bool]          `form:"active"`
+	Since   optionalv2.Option[time.Time]     `form:"since"`
+	Timeout optionalv2.Option[time.Duration] `form:"timeout"`
+	Tags    optionalv2.Option[[]string]      `form:"tag"`
+	IDs     optionalv2.Option[[]int64]       `form:"id"`
+	Sort    optionalv2.Option[string]
+	Ignored optionalv2.Option[string] `form:"-"`
+	Plain   string                    `form:"plain"`
+}
+
+func TestDecode(t *testing.T) {
+	// Test absent, empty and valued keys
+	t.Run("States", func(t *testing.T) {
+		values, err := url.ParseQuery("limit=20&cursor=&active=true&Sort=name&plain=x&Ignored=y")
+		assert.NoError(t, err)
+
+		var p listParams
+		assert.NoError(t, optionalform.Decode(values, &p))
+		assert.Equal(t, optionalv2.StateSome, p.Limit.State())
+		assert.Equal(t, 20, p.Limit.Unwrap())
+		assert.Equal(t, optionalv2.StateNull, p.Cursor.State())
+		assert.True(t, p.Active.Unwrap())
+		assert.Equal(t, "name", p.Sort.Unwrap())
+		assert.True(t, p.Since.IsNone())
+		assert.True(t, p.Tags.IsNone())
+		assert.True(t, p.Ignored.IsNone())
+		assert.Equal(t, "", p.Plain)
+	})
+
+	// Test zero values are kept, so they can be told apart from empty values
+	t.Run("ZeroValues", func(t *testing.T) {
+		values, err := url.ParseQuery("limit=0&active=false&cursor=")
+		assert.NoError(t, err)
+
+		var p listParams
+		assert.NoError(t, optionalform.Decode(values, &p))
+		assert.Equal(t, optionalv2.StateSome, p.Limit.State())
+		assert.Equal(t, 0, p.Limit.Unwrap())
+		assert.Equal(t, optionalv2.StateSome, p.Active.State())
+		assert.False(t, p.Active.Unwrap())
+		assert.Equal(t, optionalv2.StateNull, p.Cursor.State())
+
+		encoded, err := optionalform.Encode(p)
+		assert.NoError(t, err)
+		assert.Equal(t, url.Values{"limit": {"0"}, "active": {"false"}, "cursor": {""}}, encoded)
+	})
+
+	// Test the EmptyAsNone policy
+	t.Run("EmptyAsNone", func(t *testing.T) {
+		values, err := url.ParseQuery("limit=&cursor=abc")
+		assert.NoError(t, err)
+
+		var p listParams
+		d := optionalform.Decoder{Empty: optionalform.EmptyAsNone}
+		assert.NoError(t, d.Decode(values, &p))
+		assert.True(t, p.Limit.IsNone())
+		assert.Equal(t, "abc", p.Cursor.Unwrap())
+	})
+
+	// Test times, durations and slices
+	t.Run("Types", func(t *testing.T) {
+		values, err := url.ParseQuery("since=2024-09-13T00:00:00Z&timeout=1m30s&tag=a&tag=b&id=1&id=2")
+		assert.NoError(t, err)
+
+		var p listParams
+		assert.NoError(t, optionalform.Decode(values, &p))
+		assert.Equal(t, time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC), p.Since.Unwrap())
+		assert.Equal(t, 90*time.Second, p.Timeout.Unwrap())
+		assert.Equal(t, []string{"a", "b"}, p.Tags.Unwrap())
+		assert.Equal(t, []int64{1, 2}, p.IDs.Unwrap())
+
+		d := optionalform.Decoder{TimeLayout: time.DateOnly}
+		p = listParams{}
+		assert.NoError(t, d.Decode(url.Values{"since": {"2024-09-13"}}, &p))
+		assert.Equal(t, time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC), p.Since.Unwrap())
+	})
+
+	// Test that all parse errors are reported
+	t.Run("Errors", func(t *testing.T) {
+		var p listParams
+		err := optionalform.Decode(url.Values{"limit": {"ten"}, "active": {"maybe"}, "cursor": {"c"}}, &p)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `decode "limit"`)
+		assert.Contains(t, err.Error(), `decode "active"`)
+		assert.Equal(t, "c", p.Cursor.Unwrap())
+
+		assert.ErrorIs(t, optionalform.Decode(url.Values{}, p), optionalform.ErrInvalidDestination)
+	})
+
+	// Test decoding multipart forms, including files
+	t.Run("MultipartForm", func(t *testing.T) {
+		type upload struct {
+			Title       optionalv2.Option[string]                  `form:"title"`
+			Avatar      optionalv2.Option[*multipart.FileHeader]   `form:"avatar"`
+			Attachments optionalv2.Option[[]*multipart.FileHeader] `form:"attachment"`
+		}
+
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		assert.NoError(t, w.WriteField("title", ""))
+		for _, name := range []string{"a.txt", "b.txt"} {
+			fw, err := w.CreateFormFile("attachment", name)
+			assert.NoError(t, err)
+			_, err = fw.Write([]byte(name))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, w.Close())
+
+		form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+		assert.NoError(t, err)
+		defer form.RemoveAll()
+
+		var u upload
+		assert.NoError(t, optionalform.DecodeForm(form, &u))
+		assert.Equal(t, optionalv2.StateNull, u.Title.State())
+		assert.True(t, u.Avatar.IsNone())
+		assert.Len(t, u.Attachments.Unwrap(), 2)
+		assert.Equal(t, "b.txt", u.Attachments.Unwrap()[1].Filename)
+	})
+}
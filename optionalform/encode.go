@@ -0,0 +1,79 @@
+package optionalform
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+// ErrInvalidSource represents the error that is raised when Encode doesn't receive a struct or a pointer to a struct.
+var ErrInvalidSource = errors.New("source must be a struct or a pointer to a struct")
+
+// Encoder encodes structs of Option fields into url.Values.
+// The zero value is ready to use.
+type Encoder struct {
+	// TimeLayout is the layout used to format time.Time values. The default is time.RFC3339Nano.
+	TimeLayout string
+}
+
+// Encode encodes the Option fields of src into url.Values using the default Encoder.
+func Encode(src any) (url.Values, error) {
+	return (&Encoder{}).Encode(src)
+}
+
+// Encode encodes the Option fields of src into url.Values.
+// None fields are omitted, null fields are encoded as an empty value, and Some fields are encoded as their value
+// (slices as repeated keys).
+func (e *Encoder) Encode(src any) (url.Values, error) {
+	v := reflect.ValueOf(src)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, ErrInvalidSource
+	}
+
+	values := url.Values{}
+	var errs []error
+	for _, f := range optionFields(v.Type()) {
+		key := f.Name
+		field, ok := f.Value(v)
+		if !ok {
+			// if field is promoted through a nil embedded pointer, omit it
+			continue
+		}
+		state, inner := optionalv2.ReflectGet(field)
+		switch state {
+		case optionalv2.StateNone:
+			continue
+		case optionalv2.StateNull:
+			values.Set(key, "")
+			continue
+		}
+
+		if !isSlice(inner.Type()) {
+			s, err := formatValue(inner, e.TimeLayout)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("encode %q: %w", key, err))
+				continue
+			}
+			values.Set(key, s)
+			continue
+		}
+		for i := 0; i < inner.Len(); i++ {
+			s, err := formatValue(inner.Index(i), e.TimeLayout)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("encode %q: %w", key, err))
+				break
+			}
+			values.Add(key, s)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return values, nil
+}
@@ -0,0 +1,61 @@
+package optionalform_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/optionalform"
+)
+
+func TestEncode(t *testing.T) {
+	// Test the three states are encoded
+	t.Run("States", func(t *testing.T) {
+		p := listParams{
+			pagination: pagination{Limit: optionalv2.Some(20), Cursor: optionalv2.Some("")},
+			Tags:       optionalv2.Some([]string{"a", "b"}),
+			Since:      optionalv2.Some(time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)),
+			Timeout:    optionalv2.Some(time.Second),
+			Ignored:    optionalv2.Some("hidden"),
+		}
+		values, err := optionalform.Encode(&p)
+		assert.NoError(t, err)
+		assert.Equal(t, "cursor=&limit=20&since=2024-09-13T00%3A00%3A00Z&tag=a&tag=b&timeout=1s", values.Encode())
+
+		e := optionalform.Encoder{TimeLayout: time.DateOnly}
+		values, err = e.Encode(p)
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-09-13", values.Get("since"))
+	})
+
+	// Test that encoding and decoding preserve the three states
+	t.Run("RoundTrip", func(t *testing.T) {
+		in := listParams{
+			pagination: pagination{Cursor: optionalv2.Some("")},
+			Active:     optionalv2.Some(true),
+			IDs:        optionalv2.Some([]int64{3, 4}),
+		}
+		values, err := optionalform.Encode(in)
+		assert.NoError(t, err)
+
+		var out listParams
+		assert.NoError(t, optionalform.Decode(values, &out))
+		assert.True(t, out.Limit.IsNone())
+		assert.Equal(t, optionalv2.StateNull, out.Cursor.State())
+		assert.True(t, out.Active.Unwrap())
+		assert.Equal(t, []int64{3, 4}, out.IDs.Unwrap())
+	})
+
+	// Test invalid sources and unsupported values
+	t.Run("Errors", func(t *testing.T) {
+		_, err := optionalform.Encode("nope")
+		assert.ErrorIs(t, err, optionalform.ErrInvalidSource)
+
+		type unsupported struct {
+			Meta optionalv2.Option[map[string]int] `form:"meta"`
+		}
+		_, err = optionalform.Encode(unsupported{Meta: optionalv2.Some(map[string]int{"a": 1})})
+		assert.Error(t, err)
+	})
+}
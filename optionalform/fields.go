@@ -0,0 +1,48 @@
+package optionalform
+
+import (
+	"reflect"
+	"time"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/internal/structfields"
+	"github.com/tapp-ai/go-optional-v2/internal/textconv"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// optionFields returns the exported Option fields of the struct type t, flattening embedded structs and pointers to
+// structs. Fields are keyed by their `form` tag, falling back to the Go field name, and a `form:"-"` tag skips the field.
+func optionFields(t reflect.Type) []structfields.Field {
+	var fields []structfields.Field
+	for _, f := range structfields.Fields(t, structfields.Tag("form")) {
+		if optionalv2.IsOptionType(f.Type) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// isSlice reports whether values of type t are encoded as repeated keys.
+func isSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+func parseValue(s string, dst reflect.Value, timeLayout string) error {
+	if dst.Type() == timeType && timeLayout != "" {
+		tm, err := time.Parse(timeLayout, s)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(tm))
+		return nil
+	}
+	return textconv.Parse(s, dst)
+}
+
+func formatValue(v reflect.Value, timeLayout string) (string, error) {
+	if v.Type() == timeType && timeLayout != "" {
+		return v.Interface().(time.Time).Format(timeLayout), nil
+	}
+	return textconv.Format(v)
+}
@@ -0,0 +1,248 @@
+// Package optionalgql coerces GraphQL input objects into structs of Option fields, and serializes Option fields for
+// resolvers.
+//
+// GraphQL distinguishes an argument or input field that is omitted from one that is explicitly `null`, which maps to
+// None and null: an absent key decodes to None, a `null` value decodes to null, and any other value is coerced and
+// decodes to Some, including zero values like `false` and `0`.
+//
+//	type UpdateUserInput struct {
+//		ID    string                    `json:"id"`
+//		Name  optionalv2.Option[string] `json:"name"`
+//		Email optionalv2.Option[string] `json:"email"`
+//	}
+//
+//	var input UpdateUserInput
+//	err := optionalgql.Decode(args["input"].(map[string]any), &input)
+//
+// Fields are named after their `json` tag like gqlgen models, falling back to the Go field name, and a `json:"-"` tag
+// skips the field. Embedded structs and pointers to structs are flattened like encoding/json does.
+//
+// The package doesn't depend on a GraphQL server library: custom scalars implement the local Unmarshaler and
+// Marshaler interfaces, which have the same shape as gqlgen's graphql.Unmarshaler and graphql.Marshaler.
+package optionalgql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/internal/structfields"
+	"github.com/tapp-ai/go-optional-v2/internal/textconv"
+)
+
+// Unmarshaler is implemented by custom scalars that coerce themselves from input values, like gqlgen's
+// graphql.Unmarshaler.
+type Unmarshaler interface {
+	UnmarshalGQL(v any) error
+}
+
+// Marshaler is implemented by custom scalars that serialize themselves, like gqlgen's graphql.Marshaler.
+type Marshaler interface {
+	MarshalGQL(w io.Writer)
+}
+
+var (
+	// ErrInvalidDestination represents the error that is raised when Decode doesn't receive a pointer to a struct.
+	ErrInvalidDestination = errors.New("destination must be a non-nil pointer to a struct")
+	// ErrInvalidValue represents the error that is raised when an input value can't be coerced to the type of a field.
+	ErrInvalidValue = errors.New("invalid input value")
+)
+
+var unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+
+// Decode coerces the input object into the struct pointed to by dst.
+//
+// Option fields become None when their key is absent, null when its value is nil, and Some otherwise. Other fields are
+// left unchanged when their key is absent. Nested input objects are decoded recursively, a single value is coerced to a
+// one-element list like the GraphQL specification requires, strings are parsed for other types that can be read from
+// text (e.g. time.Time, or an ID stored as an int), and types implementing Unmarshaler coerce themselves.
+// All coercion errors are reported together.
+func Decode(input map[string]any, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+	return decodeStruct(input, v.Elem(), "")
+}
+
+func decodeStruct(input map[string]any, v reflect.Value, prefix string) error {
+	var errs []error
+	for _, f := range structfields.Fields(v.Type(), structfields.Tag("json")) {
+		raw, ok := input[f.Name]
+		// if key is absent
+		if !ok {
+			if field, ok := f.Value(v); ok && optionalv2.IsOptionType(f.Type) {
+				optionalv2.ReflectSet(field, optionalv2.StateNone, reflect.Value{})
+			}
+			continue
+		}
+		field, err := f.Alloc(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("coerce %q: %w", prefix+f.Name, err))
+			continue
+		}
+		if err := coerce(raw, field, prefix+f.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// coerce stores the input value raw in dst, which must be settable.
+func coerce(raw any, dst reflect.Value, path string) error {
+	t := dst.Type()
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		if err := dst.Addr().Interface().(Unmarshaler).UnmarshalGQL(raw); err != nil {
+			return fmt.Errorf("coerce %q: %w", path, err)
+		}
+		return nil
+	}
+
+	switch {
+	case optionalv2.IsOptionType(t):
+		// if value is `null`
+		if raw == nil {
+			optionalv2.ReflectSet(dst, optionalv2.StateNull, reflect.Value{})
+			return nil
+		}
+		// otherwise, we have an actual value, so coerce it
+		v := reflect.New(optionalv2.ReflectValueType(t)).Elem()
+		if err := coerce(raw, v, path); err != nil {
+			return err
+		}
+		// keep zero values, so that `false` isn't mistaken for `null`
+		optionalv2.ReflectSetValue(dst, v)
+		return nil
+	case raw == nil:
+		dst.Set(reflect.Zero(t))
+		return nil
+	case t.Kind() == reflect.Pointer:
+		v := reflect.New(t.Elem())
+		if err := coerce(raw, v.Elem(), path); err != nil {
+			return err
+		}
+		dst.Set(v)
+		return nil
+	}
+
+	rv := reflect.ValueOf(raw)
+	if rv.Type().AssignableTo(t) {
+		dst.Set(rv)
+		return nil
+	}
+	if s, ok := raw.(string); ok && t.Kind() != reflect.String && textconv.Supports(t) {
+		// e.g. a time.Time, or an ID stored as an int
+		if err := textconv.Parse(s, dst); err != nil {
+			return fmt.Errorf("coerce %q: %w", path, err)
+		}
+		return nil
+	}
+
+	var err error
+	switch t.Kind() {
+	case reflect.Struct:
+		if input, ok := raw.(map[string]any); ok {
+			return decodeStruct(input, dst, path+".")
+		}
+		err = invalidValue(raw, t)
+	case reflect.Slice:
+		items, ok := raw.([]any)
+		if !ok {
+			// input coercion of a single value to a list
+			items = []any{raw}
+		}
+		s := reflect.MakeSlice(t, len(items), len(items))
+		var errs []error
+		for i, item := range items {
+			errs = append(errs, coerce(item, s.Index(i), fmt.Sprintf("%s[%d]", path, i)))
+		}
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+		dst.Set(s)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = toInt(raw); err == nil {
+			if dst.OverflowInt(n) {
+				err = fmt.Errorf("%w: %d overflows %s", ErrInvalidValue, n, t)
+			} else {
+				dst.SetInt(n)
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n int64
+		if n, err = toInt(raw); err == nil {
+			if n < 0 || dst.OverflowUint(uint64(n)) {
+				err = fmt.Errorf("%w: %d overflows %s", ErrInvalidValue, n, t)
+			} else {
+				dst.SetUint(uint64(n))
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = toFloat(raw); err == nil {
+			dst.SetFloat(f)
+		}
+	default:
+		if rv.Kind() == t.Kind() && rv.Type().ConvertibleTo(t) {
+			// e.g. a string for a named string type such as an enum
+			dst.Set(rv.Convert(t))
+		} else {
+			err = invalidValue(raw, t)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("coerce %q: %w", path, err)
+	}
+	return nil
+}
+
+// toInt converts the numbers a GraphQL server may produce for an Int (e.g. int, int64, float64 or json.Number).
+func toInt(raw any) (int64, error) {
+	switch n := raw.(type) {
+	case int:
+		return int64(n), nil
+	case int32:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case float64:
+		if n != math.Trunc(n) || n < math.MinInt64 || n >= math.MaxInt64 {
+			return 0, fmt.Errorf("%w: %v is not an integer", ErrInvalidValue, n)
+		}
+		return int64(n), nil
+	case json.Number:
+		return n.Int64()
+	default:
+		return 0, invalidValue(raw, reflect.TypeOf(int64(0)))
+	}
+}
+
+// toFloat converts the numbers a GraphQL server may produce for a Float.
+func toFloat(raw any) (float64, error) {
+	switch n := raw.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case int32:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case json.Number:
+		return n.Float64()
+	default:
+		return 0, invalidValue(raw, reflect.TypeOf(float64(0)))
+	}
+}
+
+func invalidValue(raw any, t reflect.Type) error {
+	return fmt.Errorf("%w: cannot coerce %T to %s", ErrInvalidValue, raw, t)
+}
@@ -0,0 +1,175 @@
+package optionalgql_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/optionalgql"
+)
+
+// upperString is a gqlgen-style custom scalar.
+type upperString string
+
+func (u *upperString) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("upperString must be a string")
+	}
+	*u = upperString(s + "!")
+	return nil
+}
+
+func (u upperString) MarshalGQL(w io.Writer) {
+	_, _ = io.WriteString(w, strconv.Quote(string(u)))
+}
+
+type role string
+
+type addressInput struct {
+	City    optionalv2.Option[string] `json:"city"`
+	Country string                    `json:"country"`
+}
+
+type auditInput struct {
+	Reason optionalv2.Option[string] `json:"reason"`
+}
+
+type updateUserInput struct {
+	auditInput
+	ID       int                             `json:"id"`
+	Name     optionalv2.Option[string]       `json:"name"`
+	Age      optionalv2.Option[int]          `json:"age"`
+	Score    optionalv2.Option[float64]      `json:"score"`
+	Admin    optionalv2.Option[bool]         `json:"admin"`
+	Role     optionalv2.Option[role]         `json:"role"`
+	Birthday optionalv2.Option[time.Time]    `json:"birthday"`
+	Tags     optionalv2.Option[[]string]     `json:"tags"`
+	Address  optionalv2.Option[addressInput] `json:"address"`
+	Nick     optionalv2.Option[upperString]  `json:"nick"`
+	Extra    map[string]any                  `json:"extra"`
+	Ignored  optionalv2.Option[string]       `json:"-"`
+	Plain    string                          `json:"plain"`
+}
+
+func TestDecode(t *testing.T) {
+	// Test absent, null and valued input fields
+	t.Run("States", func(t *testing.T) {
+		input := map[string]any{
+			"id":      "7",
+			"name":    "Alice",
+			"age":     nil,
+			"admin":   false,
+			"reason":  "support ticket",
+			"Ignored": "x",
+		}
+		in := updateUserInput{Plain: "kept"}
+		assert.NoError(t, optionalgql.Decode(input, &in))
+		assert.Equal(t, 7, in.ID)
+		assert.Equal(t, optionalv2.Some("Alice"), in.Name)
+		assert.Equal(t, optionalv2.StateNull, in.Age.State())
+		assert.Equal(t, optionalv2.StateSome, in.Admin.State())
+		assert.True(t, in.Score.IsNone())
+		assert.True(t, in.Address.IsNone())
+		assert.True(t, in.Ignored.IsNone())
+		assert.Equal(t, optionalv2.Some("support ticket"), in.Reason)
+		assert.Equal(t, "kept", in.Plain)
+	})
+
+	// Test zero values are kept, so they can be told apart from `null`
+	t.Run("ZeroValues", func(t *testing.T) {
+		var in updateUserInput
+		assert.NoError(t, optionalgql.Decode(map[string]any{"admin": false, "age": 0, "name": ""}, &in))
+		assert.Equal(t, optionalv2.StateSome, in.Admin.State())
+		assert.False(t, in.Admin.Unwrap())
+		assert.Equal(t, optionalv2.StateSome, in.Age.State())
+		assert.Equal(t, 0, in.Age.Unwrap())
+		assert.Equal(t, optionalv2.StateSome, in.Name.State())
+		assert.Equal(t, "", in.Name.Unwrap())
+	})
+
+	// Test the fields of an embedded pointer are decoded, allocating it only when one of them is present
+	t.Run("EmbeddedPointer", func(t *testing.T) {
+		type AuditInput auditInput
+		type input struct {
+			*AuditInput
+			ID int `json:"id"`
+		}
+		var in input
+		assert.NoError(t, optionalgql.Decode(map[string]any{"id": 1}, &in))
+		assert.Nil(t, in.AuditInput)
+
+		assert.NoError(t, optionalgql.Decode(map[string]any{"reason": nil}, &in))
+		assert.Equal(t, optionalv2.StateNull, in.Reason.State())
+
+		data, err := optionalgql.Encode(in)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"id": 1, "reason": nil}, data)
+	})
+
+	// Test coercion of the values produced by GraphQL servers
+	t.Run("Coercion", func(t *testing.T) {
+		input := map[string]any{
+			"id":       int64(7),
+			"age":      json.Number("42"),
+			"score":    int64(3),
+			"admin":    true,
+			"role":     "ADMIN",
+			"birthday": "2000-01-02T00:00:00Z",
+			"tags":     "single",
+			"address":  map[string]any{"city": nil, "country": "FR"},
+			"nick":     "bob",
+			"extra":    map[string]any{"a": 1.0},
+		}
+		var in updateUserInput
+		assert.NoError(t, optionalgql.Decode(input, &in))
+		assert.Equal(t, 7, in.ID)
+		assert.Equal(t, optionalv2.Some(42), in.Age)
+		assert.Equal(t, optionalv2.Some(3.0), in.Score)
+		assert.Equal(t, optionalv2.Some(role("ADMIN")), in.Role)
+		assert.Equal(t, optionalv2.Some(time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)), in.Birthday)
+		assert.Equal(t, optionalv2.Some([]string{"single"}), in.Tags)
+		assert.Equal(t, optionalv2.StateNull, in.Address.Unwrap().City.State())
+		assert.Equal(t, "FR", in.Address.Unwrap().Country)
+		assert.Equal(t, optionalv2.Some(upperString("bob!")), in.Nick)
+		assert.Equal(t, map[string]any{"a": 1.0}, in.Extra)
+	})
+
+	// Test lists of values
+	t.Run("List", func(t *testing.T) {
+		var in updateUserInput
+		assert.NoError(t, optionalgql.Decode(map[string]any{"tags": []any{"a", "b"}}, &in))
+		assert.Equal(t, optionalv2.Some([]string{"a", "b"}), in.Tags)
+	})
+
+	// Test all coercion errors are reported with their path
+	t.Run("Errors", func(t *testing.T) {
+		input := map[string]any{
+			"age":     1.5,
+			"admin":   "yes",
+			"tags":    []any{"a", 1},
+			"address": map[string]any{"country": 1},
+			"nick":    1,
+		}
+		var in updateUserInput
+		err := optionalgql.Decode(input, &in)
+		assert.ErrorIs(t, err, optionalgql.ErrInvalidValue)
+		assert.ErrorContains(t, err, `coerce "age"`)
+		assert.ErrorContains(t, err, `coerce "admin"`)
+		assert.ErrorContains(t, err, `coerce "tags[1]"`)
+		assert.ErrorContains(t, err, `coerce "address.country"`)
+		assert.ErrorContains(t, err, `coerce "nick": upperString must be a string`)
+	})
+
+	// Test invalid destinations
+	t.Run("InvalidDestination", func(t *testing.T) {
+		var in updateUserInput
+		assert.ErrorIs(t, optionalgql.Decode(nil, in), optionalgql.ErrInvalidDestination)
+		assert.ErrorIs(t, optionalgql.Decode(nil, (*updateUserInput)(nil)), optionalgql.ErrInvalidDestination)
+	})
+}
@@ -0,0 +1,97 @@
+package optionalgql
+
+import (
+	"encoding"
+	"errors"
+	"reflect"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/internal/structfields"
+)
+
+// ErrInvalidSource represents the error that is raised when Encode doesn't receive a struct or a pointer to a struct.
+var ErrInvalidSource = errors.New("source must be a struct or a non-nil pointer to a struct")
+
+var (
+	marshalerType     = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Encode serializes the struct src into a map that a resolver can return for an object type.
+//
+// None fields are omitted, null fields are nil, and Some fields hold their value. Nested structs (including those held
+// by Options, pointers and slices) are serialized recursively, except for types implementing Marshaler or
+// encoding.TextMarshaler (e.g. time.Time), which are kept as is.
+func Encode(src any) (map[string]any, error) {
+	v := reflect.ValueOf(src)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, ErrInvalidSource
+	}
+	return encodeStruct(v), nil
+}
+
+func encodeStruct(v reflect.Value) map[string]any {
+	result := map[string]any{}
+	for _, f := range structfields.Fields(v.Type(), structfields.Tag("json")) {
+		field, ok := f.Value(v)
+		if !ok {
+			// if field is promoted through a nil embedded pointer, omit it
+			continue
+		}
+		if optionalv2.IsOptionType(f.Type) {
+			if state, _ := optionalv2.ReflectGet(field); state == optionalv2.StateNone {
+				// if field is unspecified, omit it
+				continue
+			}
+		}
+		result[f.Name] = encodeValue(field)
+	}
+	return result
+}
+
+func encodeValue(v reflect.Value) any {
+	t := v.Type()
+	if t.Implements(marshalerType) || t.Implements(textMarshalerType) {
+		return v.Interface()
+	}
+
+	switch {
+	case optionalv2.IsOptionType(t):
+		state, inner := optionalv2.ReflectGet(v)
+		if state != optionalv2.StateSome {
+			return nil
+		}
+		return encodeValue(inner)
+	case t.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		if encodesAsMap(t.Elem()) {
+			return encodeValue(v.Elem())
+		}
+	case t.Kind() == reflect.Struct:
+		return encodeStruct(v)
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		if t.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if elem := t.Elem(); optionalv2.IsOptionType(elem) || encodesAsMap(elem) ||
+			(elem.Kind() == reflect.Pointer && encodesAsMap(elem.Elem())) {
+			items := make([]any, v.Len())
+			for i := range items {
+				items[i] = encodeValue(v.Index(i))
+			}
+			return items
+		}
+	}
+	return v.Interface()
+}
+
+// encodesAsMap reports whether values of type t are serialized as maps.
+func encodesAsMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !t.Implements(marshalerType) && !t.Implements(textMarshalerType) &&
+		!reflect.PointerTo(t).Implements(marshalerType) && !reflect.PointerTo(t).Implements(textMarshalerType)
+}
@@ -0,0 +1,70 @@
+package optionalgql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/optionalgql"
+)
+
+type address struct {
+	City    optionalv2.Option[string] `json:"city"`
+	Country string                    `json:"country"`
+}
+
+type user struct {
+	ID        int                            `json:"id"`
+	Name      optionalv2.Option[string]      `json:"name"`
+	Email     optionalv2.Option[string]      `json:"email"`
+	Age       optionalv2.Option[int]         `json:"age"`
+	CreatedAt optionalv2.Option[time.Time]   `json:"createdAt"`
+	Nick      optionalv2.Option[upperString] `json:"nick"`
+	Address   optionalv2.Option[address]     `json:"address"`
+	Previous  []*address                     `json:"previous"`
+	Scores    []optionalv2.Option[int]       `json:"scores"`
+	Tags      []string                       `json:"tags"`
+	Secret    string                         `json:"-"`
+}
+
+func TestEncode(t *testing.T) {
+	created := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	// Test None fields are omitted, null fields are nil, and nested structs become maps
+	t.Run("States", func(t *testing.T) {
+		u := user{
+			ID:        1,
+			Name:      optionalv2.Some("Alice"),
+			Age:       optionalv2.Some(0),
+			CreatedAt: optionalv2.Some(created),
+			Nick:      optionalv2.Some(upperString("al")),
+			Address:   optionalv2.Some(address{Country: "FR"}),
+			Previous:  []*address{{City: optionalv2.Some("Paris")}, nil},
+			Scores:    []optionalv2.Option[int]{optionalv2.Some(1), optionalv2.None[int]()},
+			Tags:      []string{"a"},
+			Secret:    "x",
+		}
+		out, err := optionalgql.Encode(&u)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"id":        1,
+			"name":      "Alice",
+			"age":       nil,
+			"createdAt": created,
+			"nick":      upperString("al"),
+			"address":   map[string]any{"country": "FR"},
+			"previous":  []any{map[string]any{"city": "Paris", "country": ""}, nil},
+			"scores":    []any{1, nil},
+			"tags":      []string{"a"},
+		}, out)
+	})
+
+	// Test invalid sources
+	t.Run("InvalidSource", func(t *testing.T) {
+		_, err := optionalgql.Encode(1)
+		assert.ErrorIs(t, err, optionalgql.ErrInvalidSource)
+		_, err = optionalgql.Encode((*user)(nil))
+		assert.ErrorIs(t, err, optionalgql.ErrInvalidSource)
+	})
+}
@@ -0,0 +1,51 @@
+package optionalgql
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+// Value adapts an *Option[T] to the Unmarshaler and Marshaler interfaces, so that it can be used where gqlgen expects
+// a graphql.Unmarshaler or a graphql.Marshaler (e.g. in a custom scalar or a generated model).
+type Value[T any] struct {
+	opt *optionalv2.Option[T]
+}
+
+// NewValue returns a Value that reads from and stores into o.
+func NewValue[T any](o *optionalv2.Option[T]) *Value[T] {
+	return &Value[T]{opt: o}
+}
+
+// UnmarshalGQL implements the Unmarshaler interface. A nil input value becomes null, and any other value is coerced
+// like Decode does and becomes Some.
+func (v *Value[T]) UnmarshalGQL(input any) error {
+	return coerce(input, reflect.ValueOf(v.opt).Elem(), "value")
+}
+
+// MarshalGQL implements the Marshaler interface. None and null are written as `null`, values implementing Marshaler
+// write themselves, and other values are serialized like Encode does and written as JSON.
+func (v *Value[T]) MarshalGQL(w io.Writer) {
+	o := *v.opt
+	if o.State() != optionalv2.StateSome {
+		_, _ = io.WriteString(w, "null")
+		return
+	}
+	if m, ok := any(o.Unwrap()).(Marshaler); ok {
+		m.MarshalGQL(w)
+		return
+	}
+	data, err := json.Marshal(encodeValue(reflect.ValueOf(*v.opt)))
+	if err != nil {
+		// the Marshaler interface can't report errors
+		data = optionalv2.NullBytes
+	}
+	_, _ = w.Write(data)
+}
+
+// Get returns the Option[T].
+func (v *Value[T]) Get() optionalv2.Option[T] {
+	return *v.opt
+}
@@ -0,0 +1,62 @@
+package optionalgql_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/optionalgql"
+)
+
+// interfaces of gqlgen's graphql package, to check that Value satisfies them
+var (
+	_ optionalgql.Unmarshaler = (*optionalgql.Value[int])(nil)
+	_ optionalgql.Marshaler   = (*optionalgql.Value[int])(nil)
+)
+
+func marshalGQL(m optionalgql.Marshaler) string {
+	var sb strings.Builder
+	m.MarshalGQL(&sb)
+	return sb.String()
+}
+
+func TestValue(t *testing.T) {
+	// Test UnmarshalGQL of null and values
+	t.Run("UnmarshalGQL", func(t *testing.T) {
+		var o optionalv2.Option[int]
+		v := optionalgql.NewValue(&o)
+		assert.True(t, v.Get().IsNone())
+
+		assert.NoError(t, v.UnmarshalGQL(int64(42)))
+		assert.Equal(t, optionalv2.Some(42), o)
+
+		assert.NoError(t, v.UnmarshalGQL(nil))
+		assert.Equal(t, optionalv2.StateNull, o.State())
+
+		assert.ErrorIs(t, v.UnmarshalGQL("x"), strconv.ErrSyntax)
+	})
+
+	// Test UnmarshalGQL delegates to custom scalars
+	t.Run("UnmarshalGQLScalar", func(t *testing.T) {
+		var o optionalv2.Option[upperString]
+		assert.NoError(t, optionalgql.NewValue(&o).UnmarshalGQL("bob"))
+		assert.Equal(t, optionalv2.Some(upperString("bob!")), o)
+	})
+
+	// Test MarshalGQL of the three states
+	t.Run("MarshalGQL", func(t *testing.T) {
+		none := optionalv2.None[string]()
+		null := optionalv2.Some("")
+		some := optionalv2.Some(`a"b`)
+		scalar := optionalv2.Some(upperString("x"))
+		structValue := optionalv2.Some(address{Country: "FR"})
+
+		assert.Equal(t, "null", marshalGQL(optionalgql.NewValue(&none)))
+		assert.Equal(t, "null", marshalGQL(optionalgql.NewValue(&null)))
+		assert.Equal(t, `"a\"b"`, marshalGQL(optionalgql.NewValue(&some)))
+		assert.Equal(t, `"x"`, marshalGQL(optionalgql.NewValue(&scalar)))
+		assert.JSONEq(t, `{"country":"FR"}`, marshalGQL(optionalgql.NewValue(&structValue)))
+	})
+}
@@ -0,0 +1,213 @@
+package optionalpb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/internal/structfields"
+)
+
+// Naming selects how struct fields are named in field mask paths.
+type Naming int
+
+const (
+	// JSONNames names fields after their `json` tag, falling back to the Go field name.
+	JSONNames Naming = iota
+	// ProtoNames names fields after the `name=` part of their `protobuf` tag, falling back to the Go field name.
+	ProtoNames
+)
+
+var (
+	// ErrNotStruct represents the error that is raised when a field mask function receives a value that isn't a struct.
+	ErrNotStruct = errors.New("value is not a struct or a pointer to a struct")
+	// ErrUnknownPath represents the error that is raised when a field mask path doesn't match any field.
+	ErrUnknownPath = errors.New("unknown field mask path")
+)
+
+// FieldMaskPaths returns the paths of the fields of msg that were touched, i.e. the Option fields that are Some or null.
+// The result can be used as the `paths` of a google.protobuf.FieldMask.
+//
+// Plain struct fields and Some values holding structs are walked recursively, and their touched fields are reported with
+// dotted paths (e.g. `address.city`). A null Option, or a Some Option whose struct has no touched fields, is reported as a
+// whole. Fields that aren't Options are never reported.
+func FieldMaskPaths(msg any, naming Naming) ([]string, error) {
+	v, err := structValue(msg)
+	if err != nil {
+		return nil, err
+	}
+	return touchedPaths(v, "", naming), nil
+}
+
+// ApplyFieldMask keeps the fields of the struct pointed to by dst that are covered by paths and resets all other fields
+// to their zero value (None for Option fields).
+// A path naming a nested field keeps only that field of the enclosing struct, including structs held by Some Options.
+func ApplyFieldMask(dst any, paths []string, naming Naming) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+
+	root := maskNode{}
+	for _, path := range paths {
+		if err := checkPath(v.Elem().Type(), path, naming); err != nil {
+			return err
+		}
+		root.add(strings.Split(path, "."))
+	}
+	applyMask(v.Elem(), root, naming)
+	return nil
+}
+
+// maskNode is a tree of field mask paths. A nil node covers the whole field.
+type maskNode map[string]maskNode
+
+func (n maskNode) add(segments []string) {
+	child, ok := n[segments[0]]
+	if ok && child == nil {
+		// the whole field is already covered
+		return
+	}
+	if len(segments) == 1 {
+		n[segments[0]] = nil
+		return
+	}
+	if !ok {
+		child = maskNode{}
+		n[segments[0]] = child
+	}
+	child.add(segments[1:])
+}
+
+func touchedPaths(v reflect.Value, prefix string, naming Naming) []string {
+	var paths []string
+	forEachField(v, naming, func(name string, f reflect.Value) {
+		path := prefix + name
+		if optionalv2.IsOptionType(f.Type()) {
+			state, inner := optionalv2.ReflectGet(f)
+			switch state {
+			case optionalv2.StateNone:
+				return
+			case optionalv2.StateSome:
+				if s, ok := derefStruct(inner); ok {
+					if nested := touchedPaths(s, path+".", naming); len(nested) > 0 {
+						paths = append(paths, nested...)
+						return
+					}
+				}
+			}
+			paths = append(paths, path)
+			return
+		}
+
+		if s, ok := derefStruct(f); ok {
+			paths = append(paths, touchedPaths(s, path+".", naming)...)
+		}
+	})
+	return paths
+}
+
+func applyMask(v reflect.Value, node maskNode, naming Naming) {
+	forEachField(v, naming, func(name string, f reflect.Value) {
+		child, ok := node[name]
+		switch {
+		case !ok:
+			f.Set(reflect.Zero(f.Type()))
+		case child == nil:
+			// the whole field is kept
+		case optionalv2.IsOptionType(f.Type()):
+			state, inner := optionalv2.ReflectGet(f)
+			if s, ok := derefStruct(inner); ok && state == optionalv2.StateSome {
+				applyMask(s, child, naming)
+				// the field stays Some, even if none of the kept fields are set
+				optionalv2.ReflectSetValue(f, inner)
+			}
+		default:
+			if s, ok := derefStruct(f); ok {
+				applyMask(s, child, naming)
+			}
+		}
+	})
+}
+
+// checkPath returns an error if path doesn't name a field of t.
+func checkPath(t reflect.Type, path string, naming Naming) error {
+	for _, segment := range strings.Split(path, ".") {
+		for optionalv2.IsOptionType(t) || t.Kind() == reflect.Pointer {
+			if t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			} else {
+				t = optionalv2.ReflectValueType(t)
+			}
+		}
+		if t.Kind() != reflect.Struct {
+			return fmt.Errorf("%w: %q", ErrUnknownPath, path)
+		}
+		field, ok := fieldByName(t, segment, naming)
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownPath, path)
+		}
+		t = field.Type
+	}
+	return nil
+}
+
+// forEachField calls f for every exported field of the struct v, flattening embedded structs like encoding/json.
+// Fields promoted through a nil embedded pointer are skipped.
+func forEachField(v reflect.Value, naming Naming, f func(name string, field reflect.Value)) {
+	for _, sf := range structfields.Fields(v.Type(), namer(naming)) {
+		if field, ok := sf.Value(v); ok {
+			f(sf.Name, field)
+		}
+	}
+}
+
+// fieldByName finds the field of t that has the given path name, looking through embedded structs.
+func fieldByName(t reflect.Type, name string, naming Naming) (structfields.Field, bool) {
+	for _, f := range structfields.Fields(t, namer(naming)) {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return structfields.Field{}, false
+}
+
+// namer names fields in paths: after their `json` tag, or the `name=` part of their `protobuf` tag.
+func namer(naming Naming) structfields.Namer {
+	if naming != ProtoNames {
+		return structfields.Tag("json")
+	}
+	return func(sf reflect.StructField) (string, bool) {
+		var name string
+		for _, part := range strings.Split(sf.Tag.Get("protobuf"), ",") {
+			if strings.HasPrefix(part, "name=") {
+				name = strings.TrimPrefix(part, "name=")
+			}
+		}
+		return name, true
+	}
+}
+
+// derefStruct returns the struct held by v, following a non-nil pointer.
+func derefStruct(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, v.Kind() == reflect.Struct
+}
+
+func structValue(msg any) (reflect.Value, error) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, ErrNotStruct
+	}
+	return v, nil
+}
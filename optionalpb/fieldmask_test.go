@@ -0,0 +1,152 @@
+package optionalpb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/optionalpb"
+)
+
+type addressPatch struct {
+	City   optionalv2.Option[string] `json:"city,omitempty" protobuf:"bytes,1,opt,name=city,proto3"`
+	Street optionalv2.Option[string] `json:"street,omitempty" protobuf:"bytes,2,opt,name=street_line,proto3"`
+}
+
+type auditFields struct {
+	UpdatedBy optionalv2.Option[string] `json:"updatedBy,omitempty" protobuf:"bytes,9,opt,name=updated_by,proto3"`
+}
+
+type userPatch struct {
+	auditFields
+	DisplayName optionalv2.Option[string]       `json:"displayName,omitempty" protobuf:"bytes,1,opt,name=display_name,proto3"`
+	Age         optionalv2.Option[int]          `json:"age,omitempty" protobuf:"varint,2,opt,name=age,proto3"`
+	Address     optionalv2.Option[addressPatch] `json:"address,omitempty" protobuf:"bytes,3,opt,name=address,proto3"`
+	Birthday    optionalv2.Option[time.Time]    `json:"birthday,omitempty" protobuf:"bytes,4,opt,name=birthday,proto3"`
+	Settings    settingsPatch                   `json:"settings" protobuf:"bytes,5,opt,name=settings,proto3"`
+	Internal    string                          `json:"-"`
+}
+
+type settingsPatch struct {
+	Theme optionalv2.Option[string] `json:"theme,omitempty" protobuf:"bytes,1,opt,name=theme,proto3"`
+	Muted optionalv2.Option[bool]   `json:"muted,omitempty" protobuf:"varint,2,opt,name=muted,proto3"`
+}
+
+func TestFieldMask(t *testing.T) {
+	patch := userPatch{
+		auditFields: auditFields{UpdatedBy: optionalv2.Some("admin")},
+		DisplayName: optionalv2.Some("Alice"),
+		Age:         optionalv2.Some(0),
+		Address:     optionalv2.Some(addressPatch{Street: optionalv2.Some("Main St")}),
+		Settings:    settingsPatch{Muted: optionalv2.Some(true)},
+	}
+
+	// Test paths are generated for Some and null fields, using json names
+	t.Run("PathsJSON", func(t *testing.T) {
+		paths, err := optionalpb.FieldMaskPaths(patch, optionalpb.JSONNames)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"updatedBy", "displayName", "age", "address.street", "settings.muted"}, paths)
+
+		paths, err = optionalpb.FieldMaskPaths(&userPatch{}, optionalpb.JSONNames)
+		assert.NoError(t, err)
+		assert.Empty(t, paths)
+	})
+
+	// Test paths are generated using proto names
+	t.Run("PathsProto", func(t *testing.T) {
+		paths, err := optionalpb.FieldMaskPaths(&patch, optionalpb.ProtoNames)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"updated_by", "display_name", "age", "address.street_line", "settings.muted"}, paths)
+	})
+
+	// Test that null and leaf structs are reported as a whole
+	t.Run("PathsWholeFields", func(t *testing.T) {
+		p := userPatch{
+			Address:  optionalv2.Some(addressPatch{}),
+			Birthday: optionalv2.Some(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+		}
+		paths, err := optionalpb.FieldMaskPaths(p, optionalpb.JSONNames)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"address", "birthday"}, paths)
+	})
+
+	// Test that invalid values are rejected
+	t.Run("PathsInvalid", func(t *testing.T) {
+		_, err := optionalpb.FieldMaskPaths(42, optionalpb.JSONNames)
+		assert.ErrorIs(t, err, optionalpb.ErrNotStruct)
+	})
+
+	// Test applying a mask keeps covered fields and resets the others
+	t.Run("Apply", func(t *testing.T) {
+		dst := userPatch{
+			auditFields: auditFields{UpdatedBy: optionalv2.Some("admin")},
+			DisplayName: optionalv2.Some("Bob"),
+			Age:         optionalv2.Some(30),
+			Address: optionalv2.Some(addressPatch{
+				City:   optionalv2.Some("Paris"),
+				Street: optionalv2.Some("Rue de Rivoli"),
+			}),
+			Birthday: optionalv2.Some(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Settings: settingsPatch{Theme: optionalv2.Some("dark"), Muted: optionalv2.Some(true)},
+			Internal: "kept",
+		}
+		err := optionalpb.ApplyFieldMask(&dst, []string{"age", "address.city", "settings.theme", "birthday"}, optionalpb.JSONNames)
+		assert.NoError(t, err)
+
+		assert.True(t, dst.UpdatedBy.IsNone())
+		assert.True(t, dst.DisplayName.IsNone())
+		assert.Equal(t, 30, dst.Age.Unwrap())
+		assert.Equal(t, "Paris", dst.Address.Unwrap().City.Unwrap())
+		assert.True(t, dst.Address.Unwrap().Street.IsNone())
+		assert.Equal(t, 2000, dst.Birthday.Unwrap().Year())
+		assert.Equal(t, "dark", dst.Settings.Theme.Unwrap())
+		assert.True(t, dst.Settings.Muted.IsNone())
+		assert.Equal(t, "kept", dst.Internal)
+	})
+
+	// Test a Some struct stays Some when none of its kept fields are set
+	t.Run("ApplyUnsetNested", func(t *testing.T) {
+		dst := userPatch{Address: optionalv2.Some(addressPatch{Street: optionalv2.Some("x")})}
+		assert.NoError(t, optionalpb.ApplyFieldMask(&dst, []string{"address.city"}, optionalpb.JSONNames))
+		assert.Equal(t, optionalv2.StateSome, dst.Address.State())
+		assert.True(t, dst.Address.Unwrap().City.IsNone())
+		assert.True(t, dst.Address.Unwrap().Street.IsNone())
+	})
+
+	// Test applying the paths generated from a patch, using proto names
+	t.Run("ApplyGeneratedPaths", func(t *testing.T) {
+		paths, err := optionalpb.FieldMaskPaths(patch, optionalpb.ProtoNames)
+		assert.NoError(t, err)
+
+		dst := userPatch{
+			auditFields: auditFields{UpdatedBy: optionalv2.Some("root")},
+			DisplayName: optionalv2.Some("Bob"),
+			Address:     optionalv2.Some(addressPatch{City: optionalv2.Some("Paris"), Street: optionalv2.Some("Rue")}),
+			Birthday:    optionalv2.Some(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Settings:    settingsPatch{Theme: optionalv2.Some("dark"), Muted: optionalv2.Some(false)},
+		}
+		assert.NoError(t, optionalpb.ApplyFieldMask(&dst, paths, optionalpb.ProtoNames))
+		assert.Equal(t, "root", dst.UpdatedBy.Unwrap())
+		assert.Equal(t, "Bob", dst.DisplayName.Unwrap())
+		assert.True(t, dst.Address.Unwrap().City.IsNone())
+		assert.Equal(t, "Rue", dst.Address.Unwrap().Street.Unwrap())
+		assert.True(t, dst.Birthday.IsNone())
+		assert.True(t, dst.Settings.Theme.IsNone())
+		assert.Equal(t, optionalv2.StateNull, dst.Settings.Muted.State())
+	})
+
+	// Test that unknown paths and invalid destinations are rejected
+	t.Run("ApplyInvalid", func(t *testing.T) {
+		dst := userPatch{DisplayName: optionalv2.Some("Bob")}
+		err := optionalpb.ApplyFieldMask(&dst, []string{"address.zip"}, optionalpb.JSONNames)
+		assert.ErrorIs(t, err, optionalpb.ErrUnknownPath)
+		assert.Equal(t, "Bob", dst.DisplayName.Unwrap())
+
+		err = optionalpb.ApplyFieldMask(&dst, []string{"age.value"}, optionalpb.JSONNames)
+		assert.ErrorIs(t, err, optionalpb.ErrUnknownPath)
+
+		err = optionalpb.ApplyFieldMask(dst, nil, optionalpb.JSONNames)
+		assert.ErrorIs(t, err, optionalpb.ErrNotStruct)
+	})
+}
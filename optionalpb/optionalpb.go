@@ -0,0 +1,111 @@
+// Package optionalpb converts between Option values and Protocol Buffers field representations:
+// proto3 `optional` fields (generated as pointers) and the well-known wrapper and time types.
+//
+// The converters are defined against small local interfaces that the generated types already satisfy
+// (e.g. *wrapperspb.StringValue, *timestamppb.Timestamp), so this package doesn't depend on the protobuf runtime.
+//
+// All converters map the three Option states the same way:
+// a nil message or pointer is None, a message holding the zero value is null, and anything else is Some.
+package optionalpb
+
+import (
+	"reflect"
+	"time"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+// Wrapper is implemented by the well-known wrapper messages,
+// such as *wrapperspb.StringValue, *wrapperspb.Int64Value and *wrapperspb.BoolValue.
+type Wrapper[T any] interface {
+	GetValue() T
+}
+
+// Timestamp is implemented by *timestamppb.Timestamp.
+type Timestamp interface {
+	AsTime() time.Time
+}
+
+// Duration is implemented by *durationpb.Duration.
+type Duration interface {
+	AsDuration() time.Duration
+}
+
+// FromPointer converts a proto3 `optional` field to an Option.
+func FromPointer[T any](p *T) optionalv2.Option[T] {
+	return optionalv2.FromNillable(p)
+}
+
+// ToPointer converts an Option to a proto3 `optional` field.
+// None becomes nil, and null becomes a pointer to the zero value.
+func ToPointer[T any](o optionalv2.Option[T]) *T {
+	return o.UnwrapAsPtr()
+}
+
+// FromWrapper converts a wrapper message to an Option.
+//
+//	name := optionalpb.FromWrapper[string](req.GetName())
+func FromWrapper[T any](w Wrapper[T]) optionalv2.Option[T] {
+	if isNil(w) {
+		return optionalv2.None[T]()
+	}
+	return optionalv2.Some(w.GetValue())
+}
+
+// ToWrapper converts an Option to a wrapper message using the provided constructor.
+// None becomes the zero value of W (i.e. a nil message).
+//
+//	req.Name = optionalpb.ToWrapper(name, wrapperspb.String)
+func ToWrapper[W Wrapper[T], T any](o optionalv2.Option[T], wrap func(T) W) W {
+	if o.IsNone() {
+		var w W
+		return w
+	}
+	return wrap(o.Unwrap())
+}
+
+// FromTimestamp converts a timestamp message to an Option.
+// The timestamp of the zero time.Time is treated as null.
+func FromTimestamp(ts Timestamp) optionalv2.Option[time.Time] {
+	if isNil(ts) {
+		return optionalv2.None[time.Time]()
+	}
+	return optionalv2.Some(ts.AsTime())
+}
+
+// ToTimestamp converts an Option to a timestamp message using the provided constructor (e.g. timestamppb.New).
+// None becomes the zero value of M (i.e. a nil message).
+func ToTimestamp[M Timestamp](o optionalv2.Option[time.Time], newTimestamp func(time.Time) M) M {
+	if o.IsNone() {
+		var m M
+		return m
+	}
+	return newTimestamp(o.Unwrap())
+}
+
+// FromDuration converts a duration message to an Option.
+func FromDuration(d Duration) optionalv2.Option[time.Duration] {
+	if isNil(d) {
+		return optionalv2.None[time.Duration]()
+	}
+	return optionalv2.Some(d.AsDuration())
+}
+
+// ToDuration converts an Option to a duration message using the provided constructor (e.g. durationpb.New).
+// None becomes the zero value of M (i.e. a nil message).
+func ToDuration[M Duration](o optionalv2.Option[time.Duration], newDuration func(time.Duration) M) M {
+	if o.IsNone() {
+		var m M
+		return m
+	}
+	return newDuration(o.Unwrap())
+}
+
+// isNil returns whether v is nil or an interface holding a nil pointer, as generated messages are always pointers.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
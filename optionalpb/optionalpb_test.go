@@ -0,0 +1,174 @@
+package optionalpb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/optionalpb"
+)
+
+// The types below mirror the generated well-known types, including their nil-safe getters.
+
+type StringValue struct{ Value string }
+
+func (x *StringValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func wrapString(v string) *StringValue { return &StringValue{Value: v} }
+
+type Int64Value struct{ Value int64 }
+
+func (x *Int64Value) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func wrapInt64(v int64) *Int64Value { return &Int64Value{Value: v} }
+
+type BoolValue struct{ Value bool }
+
+func (x *BoolValue) GetValue() bool {
+	if x != nil {
+		return x.Value
+	}
+	return false
+}
+
+func wrapBool(v bool) *BoolValue { return &BoolValue{Value: v} }
+
+type Timestamp struct {
+	Seconds int64
+	Nanos   int32
+}
+
+func (x *Timestamp) AsTime() time.Time {
+	if x == nil {
+		return time.Unix(0, 0).UTC()
+	}
+	return time.Unix(x.Seconds, int64(x.Nanos)).UTC()
+}
+
+func newTimestamp(t time.Time) *Timestamp {
+	return &Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
+
+type Duration struct {
+	Seconds int64
+	Nanos   int32
+}
+
+func (x *Duration) AsDuration() time.Duration {
+	if x == nil {
+		return 0
+	}
+	return time.Duration(x.Seconds)*time.Second + time.Duration(x.Nanos)
+}
+
+func newDuration(d time.Duration) *Duration {
+	return &Duration{Seconds: int64(d / time.Second), Nanos: int32(d % time.Second)}
+}
+
+func assertNull[T any](t *testing.T, o optionalv2.Option[T]) {
+	t.Helper()
+	assert.True(t, o.IsSome())
+	data, err := o.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
+
+func TestOptionalPB(t *testing.T) {
+	// Test proto3 optional pointer fields
+	t.Run("Pointer", func(t *testing.T) {
+		assert.Nil(t, optionalpb.ToPointer(optionalv2.None[string]()))
+		assert.True(t, optionalpb.FromPointer[string](nil).IsNone())
+
+		p := optionalpb.ToPointer(optionalv2.Some(""))
+		assert.NotNil(t, p)
+		assert.Equal(t, "", *p)
+		assertNull(t, optionalpb.FromPointer(p))
+
+		p = optionalpb.ToPointer(optionalv2.Some("Alice"))
+		assert.Equal(t, "Alice", *p)
+		assert.Equal(t, "Alice", optionalpb.FromPointer(p).Unwrap())
+	})
+
+	// Test the StringValue wrapper
+	t.Run("StringValue", func(t *testing.T) {
+		w := optionalpb.ToWrapper(optionalv2.None[string](), wrapString)
+		assert.Nil(t, w)
+		assert.True(t, optionalpb.FromWrapper[string](w).IsNone())
+
+		w = optionalpb.ToWrapper(optionalv2.Some(""), wrapString)
+		assert.Equal(t, &StringValue{}, w)
+		assertNull(t, optionalpb.FromWrapper[string](w))
+
+		w = optionalpb.ToWrapper(optionalv2.Some("Alice"), wrapString)
+		assert.Equal(t, &StringValue{Value: "Alice"}, w)
+		assert.Equal(t, "Alice", optionalpb.FromWrapper[string](w).Unwrap())
+	})
+
+	// Test the Int64Value wrapper
+	t.Run("Int64Value", func(t *testing.T) {
+		w := optionalpb.ToWrapper(optionalv2.None[int64](), wrapInt64)
+		assert.Nil(t, w)
+		assert.True(t, optionalpb.FromWrapper[int64](w).IsNone())
+
+		w = optionalpb.ToWrapper(optionalv2.Some[int64](0), wrapInt64)
+		assert.Equal(t, &Int64Value{}, w)
+		assertNull(t, optionalpb.FromWrapper[int64](w))
+
+		w = optionalpb.ToWrapper(optionalv2.Some[int64](42), wrapInt64)
+		assert.Equal(t, int64(42), optionalpb.FromWrapper[int64](w).Unwrap())
+	})
+
+	// Test the BoolValue wrapper
+	t.Run("BoolValue", func(t *testing.T) {
+		w := optionalpb.ToWrapper(optionalv2.None[bool](), wrapBool)
+		assert.Nil(t, w)
+		assert.True(t, optionalpb.FromWrapper[bool](w).IsNone())
+
+		w = optionalpb.ToWrapper(optionalv2.Some(false), wrapBool)
+		assert.Equal(t, &BoolValue{}, w)
+		assertNull(t, optionalpb.FromWrapper[bool](w))
+
+		w = optionalpb.ToWrapper(optionalv2.Some(true), wrapBool)
+		assert.True(t, optionalpb.FromWrapper[bool](w).Unwrap())
+	})
+
+	// Test the Timestamp message
+	t.Run("Timestamp", func(t *testing.T) {
+		ts := optionalpb.ToTimestamp(optionalv2.None[time.Time](), newTimestamp)
+		assert.Nil(t, ts)
+		assert.True(t, optionalpb.FromTimestamp(ts).IsNone())
+
+		ts = optionalpb.ToTimestamp(optionalv2.Some(time.Time{}), newTimestamp)
+		assert.NotNil(t, ts)
+		assertNull(t, optionalpb.FromTimestamp(ts))
+
+		now := time.Date(2024, 9, 13, 10, 30, 0, 500, time.UTC)
+		ts = optionalpb.ToTimestamp(optionalv2.Some(now), newTimestamp)
+		assert.Equal(t, now, optionalpb.FromTimestamp(ts).Unwrap())
+	})
+
+	// Test the Duration message
+	t.Run("Duration", func(t *testing.T) {
+		d := optionalpb.ToDuration(optionalv2.None[time.Duration](), newDuration)
+		assert.Nil(t, d)
+		assert.True(t, optionalpb.FromDuration(d).IsNone())
+
+		d = optionalpb.ToDuration(optionalv2.Some(time.Duration(0)), newDuration)
+		assert.NotNil(t, d)
+		assertNull(t, optionalpb.FromDuration(d))
+
+		d = optionalpb.ToDuration(optionalv2.Some(90*time.Second+5), newDuration)
+		assert.Equal(t, 90*time.Second+5, optionalpb.FromDuration(d).Unwrap())
+	})
+}
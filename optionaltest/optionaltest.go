@@ -0,0 +1,204 @@
+// Package optionaltest provides testify-style assertions for Option values.
+//
+//	optionaltest.AssertSome(t, user.Name, "Alice")
+//	optionaltest.AssertNull(t, user.Age)
+//	optionaltest.AssertNone(t, user.Email)
+//
+// The Assert functions mark the test as failed and return whether the assertion succeeded,
+// and the Require functions stop the test with t.FailNow instead.
+package optionaltest
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// AssertSome asserts that the Option is Some with a value equal to want.
+func AssertSome[T any](t testing.TB, o optionalv2.Option[T], want T, msgAndArgs ...any) bool {
+	t.Helper()
+	if o.State() == optionalv2.StateSome && objectsAreEqual(want, o.Unwrap()) {
+		return true
+	}
+	return fail(t, fmt.Sprintf("expected Some(%#v), got %s", want, describe(reflect.ValueOf(o))), msgAndArgs)
+}
+
+// AssertNone asserts that the Option is None.
+func AssertNone[T any](t testing.TB, o optionalv2.Option[T], msgAndArgs ...any) bool {
+	t.Helper()
+	if o.IsNone() {
+		return true
+	}
+	return fail(t, fmt.Sprintf("expected None, got %s", describe(reflect.ValueOf(o))), msgAndArgs)
+}
+
+// AssertNull asserts that the Option has an explicit null value.
+func AssertNull[T any](t testing.TB, o optionalv2.Option[T], msgAndArgs ...any) bool {
+	t.Helper()
+	if o.State() == optionalv2.StateNull {
+		return true
+	}
+	return fail(t, fmt.Sprintf("expected null, got %s", describe(reflect.ValueOf(o))), msgAndArgs)
+}
+
+// RequireSome is like AssertSome, but stops the test if the assertion fails.
+func RequireSome[T any](t testing.TB, o optionalv2.Option[T], want T, msgAndArgs ...any) {
+	t.Helper()
+	if !AssertSome(t, o, want, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// RequireNone is like AssertNone, but stops the test if the assertion fails.
+func RequireNone[T any](t testing.TB, o optionalv2.Option[T], msgAndArgs ...any) {
+	t.Helper()
+	if !AssertNone(t, o, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// RequireNull is like AssertNull, but stops the test if the assertion fails.
+func RequireNull[T any](t testing.TB, o optionalv2.Option[T], msgAndArgs ...any) {
+	t.Helper()
+	if !AssertNull(t, o, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// AssertPatchEqual asserts that two structs of Option fields are equal, comparing them field by field.
+// Nested structs are compared recursively, structs like time.Time that don't expose their fields are compared as a
+// whole, and the failure message lists every differing field with its state:
+//
+//	patches are not equal:
+//	  Name: expected Some("Alice"), got None
+//	  Address.City: expected null, got Some("Paris")
+func AssertPatchEqual[T any](t testing.TB, want, got T, msgAndArgs ...any) bool {
+	t.Helper()
+	wantValue, gotValue := reflect.ValueOf(&want).Elem(), reflect.ValueOf(&got).Elem()
+	if wantValue.Kind() != reflect.Struct {
+		return fail(t, fmt.Sprintf("expected a struct, got %s", wantValue.Type()), msgAndArgs)
+	}
+
+	diffs := diffStruct(wantValue, gotValue, "")
+	if len(diffs) == 0 {
+		return true
+	}
+	return fail(t, "patches are not equal:\n  "+strings.Join(diffs, "\n  "), msgAndArgs)
+}
+
+func diffStruct(want, got reflect.Value, prefix string) []string {
+	var diffs []string
+	for i := 0; i < want.NumField(); i++ {
+		sf := want.Type().Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		path := prefix + sf.Name
+		wantField, gotField := want.Field(i), got.Field(i)
+
+		switch {
+		case optionalv2.IsOptionType(sf.Type):
+			if !optionEqual(wantField, gotField) {
+				diffs = append(diffs, fmt.Sprintf("%s: expected %s, got %s", path, describe(wantField), describe(gotField)))
+			}
+		case isNestedStruct(sf.Type):
+			nested := path + "."
+			if sf.Anonymous {
+				nested = prefix
+			}
+			diffs = append(diffs, diffStruct(wantField, gotField, nested)...)
+		case sf.IsExported():
+			if !objectsAreEqual(wantField.Interface(), gotField.Interface()) {
+				diffs = append(diffs, fmt.Sprintf("%s: expected %#v, got %#v", path, wantField.Interface(), gotField.Interface()))
+			}
+		}
+	}
+	return diffs
+}
+
+// isNestedStruct returns whether the struct type t is compared field by field, i.e. whether it has exported fields and
+// doesn't marshal itself. Other structs, such as time.Time, are compared as a whole.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for _, marshaler := range []reflect.Type{jsonMarshalerType, textMarshalerType} {
+		if t.Implements(marshaler) || reflect.PointerTo(t).Implements(marshaler) {
+			return false
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.IsExported() || (sf.Anonymous && isNestedStruct(sf.Type)) {
+			return true
+		}
+	}
+	return false
+}
+
+func optionEqual(want, got reflect.Value) bool {
+	wantState, wantInner := optionalv2.ReflectGet(want)
+	gotState, gotInner := optionalv2.ReflectGet(got)
+	if wantState != gotState {
+		return false
+	}
+	return wantState != optionalv2.StateSome || objectsAreEqual(wantInner.Interface(), gotInner.Interface())
+}
+
+// objectsAreEqual reports whether want and got are deeply equal, comparing byte slices by content like testify does.
+func objectsAreEqual(want, got any) bool {
+	wantBytes, ok := want.([]byte)
+	if !ok {
+		return reflect.DeepEqual(want, got)
+	}
+	gotBytes, ok := got.([]byte)
+	return ok && bytes.Equal(wantBytes, gotBytes)
+}
+
+// describe formats the Option held by v with its state, e.g. `None`, `null` or `Some("Alice")`.
+func describe(v reflect.Value) string {
+	state, inner := optionalv2.ReflectGet(v)
+	switch state {
+	case optionalv2.StateNone:
+		return "None"
+	case optionalv2.StateNull:
+		return "null"
+	default:
+		return fmt.Sprintf("Some(%#v)", inner.Interface())
+	}
+}
+
+func fail(t testing.TB, message string, msgAndArgs []any) bool {
+	t.Helper()
+	if extra := formatMessage(msgAndArgs); extra != "" {
+		message += "\n" + extra
+	}
+	t.Errorf("%s", message)
+	return false
+}
+
+// formatMessage formats the optional message arguments like testify, i.e. a single value or a format string and arguments.
+func formatMessage(msgAndArgs []any) string {
+	switch len(msgAndArgs) {
+	case 0:
+		return ""
+	case 1:
+		if msg, ok := msgAndArgs[0].(string); ok {
+			return msg
+		}
+		return fmt.Sprintf("%+v", msgAndArgs[0])
+	default:
+		return fmt.Sprintf(fmt.Sprint(msgAndArgs[0]), msgAndArgs[1:]...)
+	}
+}
@@ -0,0 +1,157 @@
+package optionaltest_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+	"github.com/tapp-ai/go-optional-v2/optionaltest"
+)
+
+// recorder is a testing.TB that records failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	messages []string
+	stopped  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) FailNow() {
+	r.stopped = true
+	runtime.Goexit()
+}
+
+// run calls f with a recorder in its own goroutine, so FailNow can stop it.
+func run(t *testing.T, f func(r *recorder)) *recorder {
+	r := &recorder{TB: t}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		f(r)
+	}()
+	wg.Wait()
+	return r
+}
+
+type address struct {
+	City optionalv2.Option[string]
+}
+
+type base struct {
+	ID optionalv2.Option[int]
+}
+
+type userPatch struct {
+	base
+	Name    optionalv2.Option[string]
+	Age     optionalv2.Option[int]
+	Address address
+	Version int
+}
+
+func TestOptionalTest(t *testing.T) {
+	// Test the assertions succeed on matching states
+	t.Run("Success", func(t *testing.T) {
+		r := run(t, func(r *recorder) {
+			assert.True(t, optionaltest.AssertSome(r, optionalv2.Some([]int{1, 2}), []int{1, 2}))
+			assert.True(t, optionaltest.AssertNone(r, optionalv2.None[int]()))
+			assert.True(t, optionaltest.AssertNull(r, optionalv2.Some(0)))
+			optionaltest.RequireSome(r, optionalv2.Some("a"), "a")
+			optionaltest.RequireNone(r, optionalv2.Option[string](nil))
+			optionaltest.RequireNull(r, optionalv2.Some(""))
+
+			// byte slices are compared by content, and structs deeply
+			assert.True(t, optionaltest.AssertSome(r, optionalv2.Some([]byte("abc")), []byte("abc")))
+			assert.True(t, optionaltest.AssertSome(r, optionalv2.Some(address{City: optionalv2.Some("Paris")}), address{City: optionalv2.Some("Paris")}))
+		})
+		assert.Empty(t, r.messages)
+		assert.False(t, r.stopped)
+	})
+
+	// Test failure messages name the states
+	t.Run("Failure", func(t *testing.T) {
+		r := run(t, func(r *recorder) {
+			assert.False(t, optionaltest.AssertSome(r, optionalv2.Some(0), 0))
+			assert.False(t, optionaltest.AssertSome(r, optionalv2.Some(1), 2, "user %d", 7))
+			assert.False(t, optionaltest.AssertNone(r, optionalv2.Some("x")))
+			assert.False(t, optionaltest.AssertNull(r, optionalv2.None[int](), "age"))
+			assert.False(t, optionaltest.AssertSome(r, optionalv2.Some([]byte("abc")), []byte("abd")))
+		})
+		assert.Equal(t, []string{
+			"expected Some(0), got null",
+			"expected Some(2), got Some(1)\nuser 7",
+			`expected None, got Some("x")`,
+			"expected null, got None\nage",
+			`expected Some([]byte{0x61, 0x62, 0x64}), got Some([]byte{0x61, 0x62, 0x63})`,
+		}, r.messages)
+	})
+
+	// Test the Require functions stop the test
+	t.Run("Require", func(t *testing.T) {
+		reached := false
+		r := run(t, func(r *recorder) {
+			optionaltest.RequireSome(r, optionalv2.None[int](), 1)
+			reached = true
+		})
+		assert.True(t, r.stopped)
+		assert.False(t, reached)
+		assert.Equal(t, []string{"expected Some(1), got None"}, r.messages)
+	})
+
+	// Test AssertPatchEqual compares structs field by field
+	t.Run("AssertPatchEqual", func(t *testing.T) {
+		want := userPatch{
+			base:    base{ID: optionalv2.Some(1)},
+			Name:    optionalv2.Some("Alice"),
+			Age:     optionalv2.Some(0),
+			Address: address{City: optionalv2.Some("Paris")},
+		}
+
+		r := run(t, func(r *recorder) {
+			got := want
+			assert.True(t, optionaltest.AssertPatchEqual(r, want, got))
+		})
+		assert.Empty(t, r.messages)
+
+		r = run(t, func(r *recorder) {
+			got := userPatch{
+				base:    base{ID: optionalv2.Some(1)},
+				Age:     optionalv2.Some(3),
+				Address: address{City: optionalv2.Some("")},
+				Version: 2,
+			}
+			assert.False(t, optionaltest.AssertPatchEqual(r, want, got))
+		})
+		assert.Equal(t, []string{"patches are not equal:\n" +
+			"  Name: expected Some(\"Alice\"), got None\n" +
+			"  Age: expected null, got Some(3)\n" +
+			"  Address.City: expected Some(\"Paris\"), got null\n" +
+			"  Version: expected 0, got 2",
+		}, r.messages)
+
+		// structs that don't expose their fields are compared as a whole
+		type event struct {
+			At time.Time
+		}
+		r = run(t, func(r *recorder) {
+			assert.False(t, optionaltest.AssertPatchEqual(r, event{At: time.Unix(1, 0).UTC()}, event{At: time.Unix(2, 0).UTC()}))
+		})
+		assert.Len(t, r.messages, 1)
+		assert.Contains(t, r.messages[0], "At: expected")
+
+		r = run(t, func(r *recorder) {
+			assert.False(t, optionaltest.AssertPatchEqual(r, 1, 1))
+		})
+		assert.Equal(t, []string{"expected a struct, got int"}, r.messages)
+	})
+}
@@ -0,0 +1,238 @@
+package optionalv2
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/tapp-ai/go-optional-v2/internal/structfields"
+)
+
+// ErrIncompatibleField represents the error that is raised when ConvertPointers can't convert a field to the type of
+// the matching field.
+var ErrIncompatibleField = errors.New("incompatible field types")
+
+// FromPtrOrNull converts a nillable value to an Option like FromNillable, except that nil becomes null instead of None.
+func FromPtrOrNull[T any](v *T) Option[T] {
+	if v == nil {
+		return null[T]()
+	}
+	return Some(*v)
+}
+
+// FromPtrPtr converts a double pointer to an Option, preserving the three states: a nil pointer becomes None, a
+// pointer to a nil pointer becomes null, and anything else becomes Some.
+func FromPtrPtr[T any](v **T) Option[T] {
+	switch {
+	case v == nil:
+		return None[T]()
+	case *v == nil:
+		return null[T]()
+	default:
+		return Some(**v)
+	}
+}
+
+// ToPtr returns a pointer to a copy of the value if the Option is Some, and nil if it is None or null.
+// Unlike UnwrapAsPtr, null doesn't become a pointer to the zero value.
+func (o Option[T]) ToPtr() *T {
+	if o.State() != StateSome {
+		return nil
+	}
+	v := o[true]
+	return &v
+}
+
+// ToPtrPtr is the inverse of FromPtrPtr: None becomes a nil pointer, null becomes a pointer to a nil pointer, and Some
+// becomes a pointer to a pointer to a copy of the value.
+func (o Option[T]) ToPtrPtr() **T {
+	switch o.State() {
+	case StateNone:
+		return nil
+	case StateNull:
+		return new(*T)
+	default:
+		v := o.ToPtr()
+		return &v
+	}
+}
+
+// NilPolicy selects how a nil pointer is converted to an Option.
+type NilPolicy int
+
+const (
+	// NilAsNone converts a nil pointer to None.
+	NilAsNone NilPolicy = iota
+	// NilAsNull converts a nil pointer to null.
+	NilAsNull
+)
+
+// PointerConverter converts between structs that represent optional fields with pointers (e.g. ORM models) and structs
+// that represent them with Options (e.g. API models). The zero value is ready to use.
+type PointerConverter struct {
+	// Nil selects how a nil `*T` field is converted to an Option field. It defaults to NilAsNone.
+	// A `**T` field always preserves the three states: a nil pointer is None, and a pointer to a nil pointer is null.
+	Nil NilPolicy
+	// Tag is the struct tag used to match fields (e.g. "json" or "db"), falling back to the Go field name.
+	// By default, fields are matched by their Go name only.
+	Tag string
+}
+
+// ConvertPointers converts src into the struct pointed to by dst with the zero PointerConverter.
+// See PointerConverter.Convert for details.
+func ConvertPointers(dst, src any) error {
+	return PointerConverter{}.Convert(dst, src)
+}
+
+// Convert sets the fields of the struct pointed to by dst from the matching fields of the struct src (or pointed to by
+// src), in either direction:
+//
+//   - a `*T` field becomes None (or null, see Nil) when nil, and Some otherwise;
+//   - a `**T` field becomes None when nil, null when it points to nil, and Some otherwise;
+//   - an Option field becomes a nil `*T` when None or null, and a `**T` preserving its state.
+//
+// Values are converted recursively, so nested structs, pointers to structs and Options of structs can differ between
+// src and dst. Embedded structs and pointers to structs are flattened, and fields of dst without a match in src are
+// left unchanged. Like Some, zero values become null. The errors of all the fields that can't be converted are joined.
+func (c PointerConverter) Convert(dst, src any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Pointer && !sv.IsNil() {
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	return c.convertStruct(dv.Elem(), sv, "")
+}
+
+func (c PointerConverter) convertStruct(dst, src reflect.Value, prefix string) error {
+	srcFields := c.fields(src.Type())
+	var errs []error
+	// walk the fields of dst in declaration order, so that errors are reported in a stable order
+	for _, df := range structfields.Fields(dst.Type(), structfields.Tag(c.Tag)) {
+		name := df.Name
+		sf, ok := srcFields[name]
+		if !ok {
+			continue
+		}
+		sv, ok := sf.Value(src)
+		if !ok {
+			// the source field is promoted through a nil embedded pointer, so there is nothing to convert
+			continue
+		}
+		dv, err := df.Alloc(dst)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", prefix+name, err))
+			continue
+		}
+		errs = append(errs, c.convert(dv, sv, prefix+name))
+	}
+	return errors.Join(errs...)
+}
+
+func (c PointerConverter) convert(dst, src reflect.Value, path string) error {
+	dt, st := dst.Type(), src.Type()
+	switch {
+	case st.AssignableTo(dt):
+		dst.Set(src)
+		return nil
+	case dt.Kind() == reflect.Struct && st.Kind() == reflect.Struct && !IsOptionType(dt) && !IsOptionType(st):
+		return c.convertStruct(dst, src, path+".")
+	case IsOptionType(dt) || IsOptionType(st) || dt.Kind() == reflect.Pointer || st.Kind() == reflect.Pointer:
+		state, inner := c.decompose(src)
+		return c.compose(dst, state, inner, path)
+	default:
+		return fmt.Errorf("%w: %s (%s to %s)", ErrIncompatibleField, path, st, dt)
+	}
+}
+
+// decompose returns the state and the value of an Option, a pointer, a double pointer, or a plain value.
+func (c PointerConverter) decompose(v reflect.Value) (State, reflect.Value) {
+	switch {
+	case IsOptionType(v.Type()):
+		return ReflectGet(v)
+	case v.Kind() != reflect.Pointer:
+		return StateSome, v
+	case v.Type().Elem().Kind() == reflect.Pointer:
+		// double pointer
+		switch {
+		case v.IsNil():
+			return StateNone, reflect.Value{}
+		case v.Elem().IsNil():
+			return StateNull, reflect.Value{}
+		default:
+			return StateSome, v.Elem().Elem()
+		}
+	case v.IsNil():
+		if c.Nil == NilAsNull {
+			return StateNull, reflect.Value{}
+		}
+		return StateNone, reflect.Value{}
+	default:
+		return StateSome, v.Elem()
+	}
+}
+
+// compose sets dst, which is an Option, a pointer, a double pointer, or a plain value, to the given state and value.
+func (c PointerConverter) compose(dst reflect.Value, state State, inner reflect.Value, path string) error {
+	dt := dst.Type()
+	switch {
+	case IsOptionType(dt):
+		if state != StateSome {
+			ReflectSet(dst, state, reflect.Value{})
+			return nil
+		}
+		v := reflect.New(ReflectValueType(dt)).Elem()
+		if err := c.convert(v, inner, path); err != nil {
+			return err
+		}
+		ReflectSet(dst, StateSome, v)
+	case dt.Kind() == reflect.Pointer && dt.Elem().Kind() == reflect.Pointer:
+		// double pointer
+		switch state {
+		case StateNone:
+			dst.Set(reflect.Zero(dt))
+		case StateNull:
+			dst.Set(reflect.New(dt.Elem()))
+		default:
+			v := reflect.New(dt.Elem().Elem())
+			if err := c.convert(v.Elem(), inner, path); err != nil {
+				return err
+			}
+			dst.Set(reflect.New(dt.Elem()))
+			dst.Elem().Set(v)
+		}
+	case dt.Kind() == reflect.Pointer:
+		if state != StateSome {
+			dst.Set(reflect.Zero(dt))
+			return nil
+		}
+		v := reflect.New(dt.Elem())
+		if err := c.convert(v.Elem(), inner, path); err != nil {
+			return err
+		}
+		dst.Set(v)
+	default:
+		if state != StateSome {
+			dst.Set(reflect.Zero(dt))
+			return nil
+		}
+		return c.convert(dst, inner, path)
+	}
+	return nil
+}
+
+// fields returns the fields of the struct type t by name, flattening embedded structs and pointers to structs
+// (see structfields.Fields).
+func (c PointerConverter) fields(t reflect.Type) map[string]structfields.Field {
+	fields := structfields.Fields(t, structfields.Tag(c.Tag))
+	byName := make(map[string]structfields.Field, len(fields))
+	for _, f := range fields {
+		byName[f.Name] = f
+	}
+	return byName
+}
@@ -0,0 +1,190 @@
+package optionalv2_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+type addressRow struct {
+	City    *string
+	Country string
+}
+
+type addressAPI struct {
+	City    optionalv2.Option[string]
+	Country string
+}
+
+type auditRow struct {
+	CreatedBy *string
+}
+
+type auditAPI struct {
+	CreatedBy optionalv2.Option[string]
+}
+
+type userRow struct {
+	auditRow
+	ID       int64
+	Name     *string
+	Nickname **string
+	Age      *int
+	Address  *addressRow
+	Internal string
+}
+
+type userAPI struct {
+	auditAPI
+	ID       int64
+	Name     optionalv2.Option[string]
+	Nickname optionalv2.Option[string]
+	Age      optionalv2.Option[int]
+	Address  optionalv2.Option[addressAPI]
+}
+
+func TestPointerHelpers(t *testing.T) {
+	// Test FromPtrOrNull converts nil to null
+	t.Run("FromPtrOrNull", func(t *testing.T) {
+		assert.Equal(t, optionalv2.StateNull, optionalv2.FromPtrOrNull[int](nil).State())
+		assert.Equal(t, optionalv2.Some(1), optionalv2.FromPtrOrNull(ptr(1)))
+	})
+
+	// Test ToPtr returns nil for None and null
+	t.Run("ToPtr", func(t *testing.T) {
+		assert.Nil(t, optionalv2.None[int]().ToPtr())
+		assert.Nil(t, optionalv2.Some(0).ToPtr())
+		assert.Equal(t, ptr(1), optionalv2.Some(1).ToPtr())
+	})
+
+	// Test double pointers preserve the three states
+	t.Run("PtrPtr", func(t *testing.T) {
+		for _, o := range []optionalv2.Option[string]{
+			optionalv2.None[string](),
+			optionalv2.Some(""),
+			optionalv2.Some("a"),
+		} {
+			assert.Equal(t, o.State(), optionalv2.FromPtrPtr(o.ToPtrPtr()).State())
+			assert.Equal(t, o.Unwrap(), optionalv2.FromPtrPtr(o.ToPtrPtr()).Unwrap())
+		}
+		assert.Nil(t, optionalv2.None[int]().ToPtrPtr())
+		assert.Nil(t, *optionalv2.Some(0).ToPtrPtr())
+	})
+}
+
+func TestConvertPointers(t *testing.T) {
+	row := userRow{
+		auditRow: auditRow{CreatedBy: ptr("admin")},
+		ID:       1,
+		Name:     ptr("Alice"),
+		Nickname: new(*string),
+		Address:  &addressRow{Country: "FR"},
+		Internal: "secret",
+	}
+
+	// Test converting pointers to Options, with nil as None
+	t.Run("ToOptions", func(t *testing.T) {
+		var api userAPI
+		assert.NoError(t, optionalv2.ConvertPointers(&api, row))
+		assert.Equal(t, int64(1), api.ID)
+		assert.Equal(t, optionalv2.Some("Alice"), api.Name)
+		assert.Equal(t, optionalv2.StateNull, api.Nickname.State())
+		assert.True(t, api.Age.IsNone())
+		assert.Equal(t, optionalv2.Some("admin"), api.CreatedBy)
+		assert.True(t, api.Address.Unwrap().City.IsNone())
+		assert.Equal(t, "FR", api.Address.Unwrap().Country)
+	})
+
+	// Test NilAsNull converts nil pointers to null, but not nil double pointers
+	t.Run("NilAsNull", func(t *testing.T) {
+		var api userAPI
+		converter := optionalv2.PointerConverter{Nil: optionalv2.NilAsNull}
+		assert.NoError(t, converter.Convert(&api, &userRow{}))
+		assert.Equal(t, optionalv2.StateNull, api.Name.State())
+		assert.Equal(t, optionalv2.StateNull, api.Age.State())
+		assert.Equal(t, optionalv2.StateNull, api.Address.State())
+		assert.True(t, api.Nickname.IsNone())
+	})
+
+	// Test converting Options back to pointers
+	t.Run("ToPointers", func(t *testing.T) {
+		api := userAPI{
+			ID:       2,
+			Name:     optionalv2.Some("Bob"),
+			Nickname: optionalv2.Some(""),
+			Age:      optionalv2.Some(0),
+			Address:  optionalv2.Some(addressAPI{City: optionalv2.Some("Paris")}),
+		}
+		dst := userRow{Internal: "kept"}
+		assert.NoError(t, optionalv2.ConvertPointers(&dst, api))
+		assert.Equal(t, int64(2), dst.ID)
+		assert.Equal(t, ptr("Bob"), dst.Name)
+		assert.NotNil(t, dst.Nickname)
+		assert.Nil(t, *dst.Nickname)
+		assert.Nil(t, dst.Age)
+		assert.Nil(t, dst.CreatedBy)
+		assert.Equal(t, &addressRow{City: ptr("Paris")}, dst.Address)
+		assert.Equal(t, "kept", dst.Internal)
+	})
+
+	// Test a round trip preserves the three states of double pointers
+	t.Run("RoundTrip", func(t *testing.T) {
+		var api userAPI
+		assert.NoError(t, optionalv2.ConvertPointers(&api, row))
+		var back userRow
+		assert.NoError(t, optionalv2.ConvertPointers(&back, api))
+		row.Internal = ""
+		assert.Equal(t, row, back)
+	})
+
+	// Test matching by tag
+	t.Run("Tag", func(t *testing.T) {
+		type src struct {
+			FullName *string `db:"name"`
+			Ignored  *string `db:"-"`
+		}
+		type dst struct {
+			Name    optionalv2.Option[string] `db:"name"`
+			Ignored optionalv2.Option[string]
+		}
+		var d dst
+		converter := optionalv2.PointerConverter{Tag: "db"}
+		assert.NoError(t, converter.Convert(&d, src{FullName: ptr("Alice"), Ignored: ptr("x")}))
+		assert.Equal(t, optionalv2.Some("Alice"), d.Name)
+		assert.True(t, d.Ignored.IsNone())
+	})
+
+	// Test incompatible fields are reported and invalid arguments are rejected
+	t.Run("Errors", func(t *testing.T) {
+		type src struct {
+			A *string
+			B *int
+			C *bool
+			D *string
+		}
+		type dst struct {
+			A optionalv2.Option[int]
+			B optionalv2.Option[int]
+			C optionalv2.Option[string]
+			D optionalv2.Option[bool]
+		}
+		var d dst
+		err := optionalv2.ConvertPointers(&d, src{A: ptr("x"), B: ptr(1), C: ptr(true), D: ptr("y")})
+		assert.ErrorIs(t, err, optionalv2.ErrIncompatibleField)
+		assert.ErrorContains(t, err, "A (string to int)")
+		assert.Equal(t, optionalv2.Some(1), d.B)
+
+		// errors are reported in the declaration order of the fields
+		assert.EqualError(t, err, "incompatible field types: A (string to int)\n"+
+			"incompatible field types: C (bool to string)\n"+
+			"incompatible field types: D (string to bool)")
+
+		assert.ErrorIs(t, optionalv2.ConvertPointers(d, src{}), optionalv2.ErrNotStruct)
+		assert.ErrorIs(t, optionalv2.ConvertPointers(&d, 1), optionalv2.ErrNotStruct)
+	})
+}
@@ -0,0 +1,134 @@
+package optionalv2
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// generator mirrors the testing/quick.Generator interface, which isn't imported to keep its flags out of this package.
+type generator interface {
+	Generate(rand *rand.Rand, size int) reflect.Value
+}
+
+var generatorType = reflect.TypeOf((*generator)(nil)).Elem()
+
+// Generate implements the testing/quick.Generator interface for Option, so that quick.Check can generate Options in
+// all three states. Some values are generated like quick.Value does; if T can't be generated, only None and null
+// values are produced.
+func (o Option[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	var state State
+	switch n := rand.Intn(4); {
+	case n == 0:
+		state = StateNone
+	case n == 1:
+		state = StateNull
+	default:
+		state = StateSome
+	}
+
+	var result Option[T]
+	switch state {
+	case StateNone:
+		result = None[T]()
+	case StateNull:
+		result = null[T]()
+	default:
+		v, ok := randomValue(reflect.TypeOf((*T)(nil)).Elem(), rand, size)
+		if !ok {
+			result = null[T]()
+			break
+		}
+		result = Some(v.Interface().(T))
+	}
+	return reflect.ValueOf(result)
+}
+
+// randomValue returns a random value of type t, following the rules of testing/quick.Value.
+func randomValue(t reflect.Type, rand *rand.Rand, size int) (reflect.Value, bool) {
+	if t.Implements(generatorType) {
+		return reflect.Zero(t).Interface().(generator).Generate(rand, size), true
+	}
+
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(rand.Int()&1 == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// the arithmetic shift keeps the value in range for the size of the type
+		v.SetInt(int64(rand.Uint64()) >> (64 - t.Bits()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(rand.Uint64() >> (64 - t.Bits()))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(rand.NormFloat64() * float64(size))
+	case reflect.String:
+		runes := make([]rune, rand.Intn(size+1))
+		for i := range runes {
+			// mix ASCII with the rest of Unicode, skipping surrogates which aren't valid in strings
+			if rand.Intn(2) == 0 {
+				runes[i] = rune(rand.Intn(0x80))
+			} else {
+				runes[i] = rune(rand.Intn(0x10ffff))
+			}
+			if runes[i] >= 0xd800 && runes[i] <= 0xdfff {
+				runes[i] = 0xfffd
+			}
+		}
+		v.SetString(string(runes))
+	case reflect.Slice:
+		n := rand.Intn(size + 1)
+		v.Set(reflect.MakeSlice(t, n, n))
+		for i := 0; i < n; i++ {
+			elem, ok := randomValue(t.Elem(), rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.Index(i).Set(elem)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			elem, ok := randomValue(t.Elem(), rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.Index(i).Set(elem)
+		}
+	case reflect.Map:
+		n := rand.Intn(size + 1)
+		v.Set(reflect.MakeMapWithSize(t, n))
+		for i := 0; i < n; i++ {
+			key, ok := randomValue(t.Key(), rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			elem, ok := randomValue(t.Elem(), rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Pointer:
+		if rand.Intn(size+1) == 0 {
+			break
+		}
+		elem, ok := randomValue(t.Elem(), rand, size)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		v.Set(reflect.New(t.Elem()))
+		v.Elem().Set(elem)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				return reflect.Value{}, false
+			}
+			field, ok := randomValue(t.Field(i).Type, rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.Field(i).Set(field)
+		}
+	default:
+		return reflect.Value{}, false
+	}
+	return v, true
+}
@@ -0,0 +1,79 @@
+package optionalv2_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+type quickRecord struct {
+	Name  optionalv2.Option[string]   `json:"name,omitempty"`
+	Score optionalv2.Option[float64]  `json:"score,omitempty"`
+	IDs   optionalv2.Option[[]uint16] `json:"ids,omitempty"`
+}
+
+func TestQuick(t *testing.T) {
+	// Test the generator produces all three states
+	t.Run("Generate", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		seen := map[optionalv2.State]int{}
+		for i := 0; i < 200; i++ {
+			v := optionalv2.Option[int8]{}.Generate(r, 10)
+			seen[v.Interface().(optionalv2.Option[int8]).State()]++
+		}
+		assert.Len(t, seen, 3)
+
+		// types that can't be generated only produce None and null
+		for i := 0; i < 50; i++ {
+			v := optionalv2.Option[func()]{}.Generate(r, 10)
+			assert.NotEqual(t, optionalv2.StateSome, v.Interface().(optionalv2.Option[func()]).State())
+		}
+	})
+
+	// Test nested Options use their own generator
+	t.Run("GenerateNested", func(t *testing.T) {
+		r := rand.New(rand.NewSource(2))
+		for i := 0; i < 50; i++ {
+			v := optionalv2.Option[[]optionalv2.Option[string]]{}.Generate(r, 5)
+			for _, inner := range v.Interface().(optionalv2.Option[[]optionalv2.Option[string]]).Unwrap() {
+				assert.True(t, reflect.TypeOf(inner) == reflect.TypeOf(optionalv2.Option[string]{}))
+			}
+		}
+	})
+
+	// Test JSON round trips preserve the state and value of a struct of Options
+	t.Run("JSONRoundTrip", func(t *testing.T) {
+		property := func(in quickRecord) bool {
+			data, err := json.Marshal(in)
+			if err != nil {
+				return false
+			}
+			var out quickRecord
+			if err := json.Unmarshal(data, &out); err != nil {
+				return false
+			}
+			return optionalv2.Equal(in.Name, out.Name) &&
+				optionalv2.Equal(in.Score, out.Score) &&
+				optionalv2.EqualFunc(in.IDs, out.IDs, func(x, y []uint16) bool { return reflect.DeepEqual(x, y) || len(x)+len(y) == 0 })
+		}
+		assert.NoError(t, quick.Check(property, &quick.Config{MaxCount: 500}))
+	})
+
+	// Test gob round trips preserve the state of every Option
+	t.Run("GobRoundTrip", func(t *testing.T) {
+		property := func(in optionalv2.Option[string]) bool {
+			data, err := in.GobEncode()
+			if err != nil {
+				return false
+			}
+			var out optionalv2.Option[string]
+			return out.GobDecode(data) == nil && optionalv2.Equal(in, out)
+		}
+		assert.NoError(t, quick.Check(property, nil))
+	})
+}
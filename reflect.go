@@ -0,0 +1,138 @@
+package optionalv2
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// State describes which of the three states an Option is in.
+type State uint8
+
+const (
+	// StateNone means the Option doesn't have a value (e.g. an absent JSON field).
+	StateNone State = iota
+	// StateNull means the Option has an explicit null value (e.g. a JSON `null`).
+	StateNull
+	// StateSome means the Option has an actual value.
+	StateSome
+)
+
+// String returns the name of the State.
+func (s State) String() string {
+	switch s {
+	case StateNone:
+		return "None"
+	case StateNull:
+		return "Null"
+	case StateSome:
+		return "Some"
+	default:
+		return fmt.Sprintf("State(%d)", uint8(s))
+	}
+}
+
+// State returns the state of the Option.
+// Note that IsSome returns true for both StateNull and StateSome.
+func (o Option[T]) State() State {
+	switch {
+	case o.IsNone():
+		return StateNone
+	case o.isNull():
+		return StateNull
+	default:
+		return StateSome
+	}
+}
+
+// --- Reflection ---
+
+// reflectOption is implemented by every Option and lets the reflection helpers work without knowing T.
+type reflectOption interface {
+	State() State
+	reflectValue() reflect.Value
+	reflectValueType() reflect.Type
+}
+
+// reflectOptionSetter is implemented by every *Option.
+type reflectOptionSetter interface {
+	reflectSet(state State, v reflect.Value)
+	reflectSetValue(v reflect.Value)
+}
+
+var reflectOptionType = reflect.TypeOf((*reflectOption)(nil)).Elem()
+
+func (o Option[T]) reflectValue() reflect.Value {
+	v := new(T)
+	*v = o.Unwrap()
+	return reflect.ValueOf(v).Elem()
+}
+
+func (o Option[T]) reflectValueType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (o *Option[T]) reflectSet(state State, v reflect.Value) {
+	switch state {
+	case StateNone:
+		*o = None[T]()
+	case StateNull:
+		*o = null[T]()
+	default:
+		var inner T
+		reflect.ValueOf(&inner).Elem().Set(v)
+		*o = Some(inner)
+	}
+}
+
+func (o *Option[T]) reflectSetValue(v reflect.Value) {
+	var inner T
+	reflect.ValueOf(&inner).Elem().Set(v)
+	*o = Option[T]{true: inner}
+}
+
+// IsOptionType reports whether t is an Option type.
+func IsOptionType(t reflect.Type) bool {
+	return t != nil && t.Kind() == reflect.Map && t.Implements(reflectOptionType)
+}
+
+// ReflectValueType returns T for the Option[T] type t.
+// It panics if t is not an Option type.
+func ReflectValueType(t reflect.Type) reflect.Type {
+	if !IsOptionType(t) {
+		panic(fmt.Sprintf("optionalv2: ReflectValueType of non-option type %s", t))
+	}
+	return reflect.Zero(t).Interface().(reflectOption).reflectValueType()
+}
+
+// ReflectGet returns the state and the contained value of the Option held by v.
+// For None and null, the returned value is the zero value of T.
+// The returned value is addressable, and modifying it doesn't modify the Option.
+// It panics if v doesn't hold an Option.
+func ReflectGet(v reflect.Value) (State, reflect.Value) {
+	if !IsOptionType(v.Type()) {
+		panic(fmt.Sprintf("optionalv2: ReflectGet of non-option type %s", v.Type()))
+	}
+	o := v.Interface().(reflectOption)
+	return o.State(), o.reflectValue()
+}
+
+// ReflectSet sets the Option held by v to the given state.
+// For StateSome, inner must be assignable to T, and it follows the same rules as Some (i.e. a zero value becomes null).
+// It panics if v doesn't hold an Option or isn't settable.
+func ReflectSet(v reflect.Value, state State, inner reflect.Value) {
+	if !IsOptionType(v.Type()) {
+		panic(fmt.Sprintf("optionalv2: ReflectSet of non-option type %s", v.Type()))
+	}
+	v.Addr().Interface().(reflectOptionSetter).reflectSet(state, inner)
+}
+
+// ReflectSetValue sets the Option held by v to Some(inner), keeping zero values instead of turning them into null.
+// Decoders use it for values that were present in their input, so that e.g. `0` and `false` can be told apart from an
+// empty value. inner must be assignable to T.
+// It panics if v doesn't hold an Option or isn't settable.
+func ReflectSetValue(v, inner reflect.Value) {
+	if !IsOptionType(v.Type()) {
+		panic(fmt.Sprintf("optionalv2: ReflectSetValue of non-option type %s", v.Type()))
+	}
+	v.Addr().Interface().(reflectOptionSetter).reflectSetValue(inner)
+}
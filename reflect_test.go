@@ -0,0 +1,99 @@
+package optionalv2_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+func TestReflect(t *testing.T) {
+	// Test State
+	t.Run("State", func(t *testing.T) {
+		assert.Equal(t, optionalv2.StateNone, optionalv2.None[int]().State())
+		assert.Equal(t, optionalv2.StateNone, optionalv2.Option[int](nil).State())
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Some(0).State())
+		assert.Equal(t, optionalv2.StateSome, optionalv2.Some(1).State())
+
+		assert.Equal(t, "None", optionalv2.StateNone.String())
+		assert.Equal(t, "Null", optionalv2.StateNull.String())
+		assert.Equal(t, "Some", optionalv2.StateSome.String())
+		assert.Equal(t, "State(9)", optionalv2.State(9).String())
+	})
+
+	// Test IsOptionType and ReflectValueType
+	t.Run("Types", func(t *testing.T) {
+		optType := reflect.TypeOf(optionalv2.Option[string]{})
+		assert.True(t, optionalv2.IsOptionType(optType))
+		assert.False(t, optionalv2.IsOptionType(reflect.TypeOf(map[bool]string{})))
+		assert.False(t, optionalv2.IsOptionType(reflect.TypeOf(&optionalv2.Option[string]{})))
+		assert.False(t, optionalv2.IsOptionType(nil))
+
+		assert.Equal(t, reflect.TypeOf(""), optionalv2.ReflectValueType(optType))
+		assert.Panics(t, func() { optionalv2.ReflectValueType(reflect.TypeOf(0)) })
+	})
+
+	// Test ReflectGet
+	t.Run("ReflectGet", func(t *testing.T) {
+		state, v := optionalv2.ReflectGet(reflect.ValueOf(optionalv2.Some("a")))
+		assert.Equal(t, optionalv2.StateSome, state)
+		assert.Equal(t, "a", v.Interface())
+		assert.True(t, v.CanSet())
+
+		state, v = optionalv2.ReflectGet(reflect.ValueOf(optionalv2.Some("")))
+		assert.Equal(t, optionalv2.StateNull, state)
+		assert.Equal(t, "", v.Interface())
+
+		state, v = optionalv2.ReflectGet(reflect.ValueOf(optionalv2.None[string]()))
+		assert.Equal(t, optionalv2.StateNone, state)
+		assert.Equal(t, "", v.Interface())
+
+		state, v = optionalv2.ReflectGet(reflect.ValueOf(optionalv2.None[any]()))
+		assert.Equal(t, optionalv2.StateNone, state)
+		assert.True(t, v.IsValid())
+
+		assert.Panics(t, func() { optionalv2.ReflectGet(reflect.ValueOf(1)) })
+	})
+
+	// Test ReflectSet
+	t.Run("ReflectSet", func(t *testing.T) {
+		type MyString string
+		var s struct {
+			Name optionalv2.Option[MyString]
+		}
+		field := reflect.ValueOf(&s).Elem().Field(0)
+
+		optionalv2.ReflectSet(field, optionalv2.StateSome, reflect.ValueOf(MyString("a")))
+		assert.Equal(t, MyString("a"), s.Name.Unwrap())
+
+		// zero values follow the rules of Some
+		optionalv2.ReflectSet(field, optionalv2.StateSome, reflect.ValueOf(MyString("")))
+		assert.Equal(t, optionalv2.StateNull, s.Name.State())
+
+		optionalv2.ReflectSet(field, optionalv2.StateNone, reflect.Value{})
+		assert.Equal(t, optionalv2.StateNone, s.Name.State())
+
+		optionalv2.ReflectSet(field, optionalv2.StateNull, reflect.Value{})
+		assert.Equal(t, optionalv2.StateNull, s.Name.State())
+
+		assert.Panics(t, func() { optionalv2.ReflectSet(reflect.ValueOf(s).Field(0), optionalv2.StateNone, reflect.Value{}) })
+	})
+
+	// Test ReflectSetValue keeps zero values
+	t.Run("ReflectSetValue", func(t *testing.T) {
+		var s struct {
+			Limit optionalv2.Option[int]
+		}
+		field := reflect.ValueOf(&s).Elem().Field(0)
+
+		optionalv2.ReflectSetValue(field, reflect.ValueOf(0))
+		assert.Equal(t, optionalv2.StateSome, s.Limit.State())
+		assert.Equal(t, 0, s.Limit.Unwrap())
+
+		optionalv2.ReflectSetValue(field, reflect.ValueOf(5))
+		assert.Equal(t, optionalv2.Some(5), s.Limit)
+
+		assert.Panics(t, func() { optionalv2.ReflectSetValue(reflect.ValueOf(&s).Elem(), reflect.ValueOf(0)) })
+	})
+}
@@ -0,0 +1,71 @@
+package optionalv2
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Placement places None or null values before or after the Some values when sorting.
+type Placement int
+
+const (
+	// PlaceFirst places the values before the Some values, like SQL `NULLS FIRST`.
+	PlaceFirst Placement = iota
+	// PlaceLast places the values after the Some values, like SQL `NULLS LAST`.
+	PlaceLast
+)
+
+// CompareNoneFirst compares two Options for sorting, ordering None before null and null before Some.
+// It can be passed to slices.SortFunc.
+func CompareNoneFirst[T cmp.Ordered](a, b Option[T]) int {
+	return Comparator(cmp.Compare[T], PlaceFirst, PlaceFirst)(a, b)
+}
+
+// CompareNoneLast compares two Options for sorting, ordering Some before null and null before None.
+// It can be passed to slices.SortFunc.
+func CompareNoneLast[T cmp.Ordered](a, b Option[T]) int {
+	return Comparator(cmp.Compare[T], PlaceLast, PlaceLast)(a, b)
+}
+
+// Comparator returns a function that compares two Options for sorting.
+// Some values are ordered with compare, and None and null values are placed before or after them.
+// When None and null are placed on the same side, None is placed outermost.
+//
+//	// Some values by ascending age, then null, then None
+//	slices.SortFunc(ages, optionalv2.Comparator(cmp.Compare[int], optionalv2.PlaceLast, optionalv2.PlaceLast))
+func Comparator[T any](compare func(x, y T) int, none, null Placement) func(a, b Option[T]) int {
+	rank := func(o Option[T]) int {
+		switch o.State() {
+		case StateNone:
+			if none == PlaceFirst {
+				return -2
+			}
+			return 2
+		case StateNull:
+			if null == PlaceFirst {
+				return -1
+			}
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return func(a, b Option[T]) int {
+		rankA, rankB := rank(a), rank(b)
+		if rankA != rankB || rankA != 0 {
+			return cmp.Compare(rankA, rankB)
+		}
+		return compare(a[true], b[true])
+	}
+}
+
+// SortBy sorts s by the Option key of each element, using compare to compare the keys.
+// The sort is stable, so elements with equal keys keep their original order.
+//
+//	optionalv2.SortBy(users, func(u User) optionalv2.Option[int] { return u.Age }, optionalv2.CompareNoneLast[int])
+func SortBy[S ~[]E, E any, T any](s S, key func(E) Option[T], compare func(a, b Option[T]) int) {
+	slices.SortStableFunc(s, func(x, y E) int {
+		return compare(key(x), key(y))
+	})
+}
@@ -0,0 +1,79 @@
+package optionalv2_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+type sortRow struct {
+	ID  int
+	Age optionalv2.Option[int]
+}
+
+func sortRows() []sortRow {
+	return []sortRow{
+		{ID: 1, Age: optionalv2.Some(30)},
+		{ID: 2, Age: optionalv2.None[int]()},
+		{ID: 3, Age: optionalv2.Some(0)},
+		{ID: 4, Age: optionalv2.Some(20)},
+		{ID: 5, Age: optionalv2.None[int]()},
+		{ID: 6, Age: optionalv2.Some(30)},
+		{ID: 7, Age: optionalv2.Some(0)},
+	}
+}
+
+func ids(rows []sortRow) []int {
+	result := make([]int, len(rows))
+	for i, r := range rows {
+		result[i] = r.ID
+	}
+	return result
+}
+
+func TestSort(t *testing.T) {
+	age := func(r sortRow) optionalv2.Option[int] { return r.Age }
+
+	// Test CompareNoneFirst with slices.SortFunc
+	t.Run("CompareNoneFirst", func(t *testing.T) {
+		opts := []optionalv2.Option[int]{optionalv2.Some(2), optionalv2.Some(0), optionalv2.None[int](), optionalv2.Some(1)}
+		slices.SortFunc(opts, optionalv2.CompareNoneFirst[int])
+		assert.Equal(t, []optionalv2.State{optionalv2.StateNone, optionalv2.StateNull, optionalv2.StateSome, optionalv2.StateSome}, []optionalv2.State{opts[0].State(), opts[1].State(), opts[2].State(), opts[3].State()})
+		assert.Equal(t, 1, opts[2].Unwrap())
+		assert.Equal(t, 2, opts[3].Unwrap())
+	})
+
+	// Test stable sorting with None first
+	t.Run("SortByNoneFirst", func(t *testing.T) {
+		rows := sortRows()
+		optionalv2.SortBy(rows, age, optionalv2.CompareNoneFirst[int])
+		assert.Equal(t, []int{2, 5, 3, 7, 4, 1, 6}, ids(rows))
+	})
+
+	// Test stable sorting with None last
+	t.Run("SortByNoneLast", func(t *testing.T) {
+		rows := sortRows()
+		optionalv2.SortBy(rows, age, optionalv2.CompareNoneLast[int])
+		assert.Equal(t, []int{4, 1, 6, 3, 7, 2, 5}, ids(rows))
+	})
+
+	// Test configurable null placement
+	t.Run("Comparator", func(t *testing.T) {
+		rows := sortRows()
+		optionalv2.SortBy(rows, age, optionalv2.Comparator(cmp.Compare[int], optionalv2.PlaceFirst, optionalv2.PlaceLast))
+		assert.Equal(t, []int{2, 5, 4, 1, 6, 3, 7}, ids(rows))
+
+		rows = sortRows()
+		optionalv2.SortBy(rows, age, optionalv2.Comparator(cmp.Compare[int], optionalv2.PlaceLast, optionalv2.PlaceFirst))
+		assert.Equal(t, []int{3, 7, 4, 1, 6, 2, 5}, ids(rows))
+
+		// descending Some values
+		rows = sortRows()
+		descending := func(x, y int) int { return cmp.Compare(y, x) }
+		optionalv2.SortBy(rows, age, optionalv2.Comparator(descending, optionalv2.PlaceLast, optionalv2.PlaceLast))
+		assert.Equal(t, []int{1, 6, 4, 3, 7, 2, 5}, ids(rows))
+	})
+}
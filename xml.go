@@ -0,0 +1,121 @@
+package optionalv2
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	"github.com/tapp-ai/go-optional-v2/internal/textconv"
+)
+
+// xsiNamespace is the XML Schema instance namespace that defines the `nil` attribute.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// MarshalXML implements the xml.Marshaler interface for Option.
+// None is omitted, null is written as an empty element with `xsi:nil="true"`, and Some is written as a normal element.
+func (o Option[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// if field was unspecified, omit the element entirely
+	if o.IsNone() {
+		return nil
+	}
+
+	// if field was specified, and `null`, write a nil element
+	if o.isNull() {
+		if !hasXSIDeclaration(start) {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace})
+		}
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"})
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	}
+
+	// otherwise: we have a value, so marshal it
+	return e.EncodeElement(o[true], start)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface for Option.
+func (o *Option[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// if element is absent, UnmarshalXML won't be called
+
+	// if element is present, and marked with `xsi:nil`
+	if isXMLNil(start) {
+		*o = null[T]()
+		return d.Skip()
+	}
+
+	// otherwise, we have an actual value, so parse it
+	var v T
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface for Option.
+// None omits the attribute, null writes it with an empty value, and Some writes the formatted value.
+func (o Option[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	// an attribute with an empty name is omitted by the encoder
+	if o.IsNone() {
+		return xml.Attr{}, nil
+	}
+
+	if o.isNull() {
+		return xml.Attr{Name: name}, nil
+	}
+
+	v := o[true]
+	if marshaler, ok := interface{}(v).(xml.MarshalerAttr); ok {
+		return marshaler.MarshalXMLAttr(name)
+	}
+
+	s, err := textconv.Format(reflect.ValueOf(&v).Elem())
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: s}, nil
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface for Option.
+func (o *Option[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	// if attribute is absent, UnmarshalXMLAttr won't be called
+
+	// if attribute is present, and empty
+	if attr.Value == "" {
+		*o = null[T]()
+		return nil
+	}
+
+	var v T
+	if unmarshaler, ok := interface{}(&v).(xml.UnmarshalerAttr); ok {
+		if err := unmarshaler.UnmarshalXMLAttr(attr); err != nil {
+			return err
+		}
+	} else if err := textconv.Parse(attr.Value, reflect.ValueOf(&v).Elem()); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// isXMLNil returns whether the element carries `xsi:nil="true"`.
+func isXMLNil(start xml.StartElement) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local != "nil" || (attr.Name.Space != xsiNamespace && attr.Name.Space != "xsi") {
+			continue
+		}
+		return attr.Value == "true" || attr.Value == "1"
+	}
+	return false
+}
+
+// hasXSIDeclaration returns whether the element already declares the `xsi` namespace prefix.
+func hasXSIDeclaration(start xml.StartElement) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "xmlns:xsi" || (attr.Name.Space == "xmlns" && attr.Name.Local == "xsi") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package optionalv2_test
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+type xmlRecord struct {
+	XMLName xml.Name                     `xml:"record"`
+	ID      optionalv2.Option[int]       `xml:"id,attr"`
+	Code    optionalv2.Option[string]    `xml:"code,attr"`
+	Name    optionalv2.Option[string]    `xml:"name"`
+	Age     optionalv2.Option[int]       `xml:"age"`
+	Since   optionalv2.Option[time.Time] `xml:"since"`
+}
+
+func TestXML(t *testing.T) {
+	// Test marshalling elements in all three states
+	t.Run("MarshalElement", func(t *testing.T) {
+		r := xmlRecord{
+			Name: optionalv2.Some("Alice"),
+			Age:  optionalv2.Some(0),
+		}
+		data, err := xml.Marshal(r)
+		assert.NoError(t, err)
+		assert.Equal(t, `<record><name>Alice</name><age xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></age></record>`, string(data))
+	})
+
+	// Test unmarshalling elements in all three states
+	t.Run("UnmarshalElement", func(t *testing.T) {
+		var r xmlRecord
+		err := xml.Unmarshal([]byte(`<record xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><name>Bob</name><age xsi:nil="true"/></record>`), &r)
+		assert.NoError(t, err)
+		assert.True(t, r.Name.IsSome())
+		assert.Equal(t, "Bob", r.Name.Unwrap())
+		assert.True(t, r.Age.IsSome())
+		assert.Equal(t, 0, r.Age.Unwrap())
+		assert.True(t, r.Since.IsNone())
+
+		// an undeclared `xsi` prefix is still recognized
+		r = xmlRecord{}
+		err = xml.Unmarshal([]byte(`<record><since xsi:nil="true"></since></record>`), &r)
+		assert.NoError(t, err)
+		assert.True(t, r.Since.IsSome())
+		assert.Equal(t, time.Time{}, r.Since.Unwrap())
+		assert.True(t, r.Name.IsNone())
+	})
+
+	// Test round trip of elements
+	t.Run("RoundTripElement", func(t *testing.T) {
+		since := time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)
+		in := xmlRecord{
+			Age:   optionalv2.Some(0),
+			Since: optionalv2.Some(since),
+		}
+		data, err := xml.Marshal(in)
+		assert.NoError(t, err)
+
+		var out xmlRecord
+		assert.NoError(t, xml.Unmarshal(data, &out))
+		assert.True(t, out.Name.IsNone())
+		assert.True(t, out.Age.IsSome())
+		assert.Equal(t, 0, out.Age.Unwrap())
+		assert.Equal(t, since, out.Since.Unwrap())
+	})
+
+	// Test attributes in all three states
+	t.Run("Attributes", func(t *testing.T) {
+		r := xmlRecord{
+			ID:   optionalv2.Some(7),
+			Code: optionalv2.Some(""),
+		}
+		data, err := xml.Marshal(r)
+		assert.NoError(t, err)
+		assert.Equal(t, `<record id="7" code=""></record>`, string(data))
+
+		data, err = xml.Marshal(xmlRecord{})
+		assert.NoError(t, err)
+		assert.Equal(t, `<record></record>`, string(data))
+
+		var out xmlRecord
+		assert.NoError(t, xml.Unmarshal([]byte(`<record id="7" code=""></record>`), &out))
+		assert.True(t, out.ID.IsSome())
+		assert.Equal(t, 7, out.ID.Unwrap())
+		assert.True(t, out.Code.IsSome())
+		assert.Equal(t, "", out.Code.Unwrap())
+
+		out = xmlRecord{}
+		assert.NoError(t, xml.Unmarshal([]byte(`<record code="X1"></record>`), &out))
+		assert.True(t, out.ID.IsNone())
+		assert.Equal(t, "X1", out.Code.Unwrap())
+
+		out = xmlRecord{}
+		assert.Error(t, xml.Unmarshal([]byte(`<record id="seven"></record>`), &out))
+	})
+}
@@ -0,0 +1,98 @@
+package optionalv2
+
+import (
+	"reflect"
+	"time"
+)
+
+// Zeroer is implemented by types that decide themselves whether they are their zero value, i.e. whether Some turns
+// them into null, so that types whose zero value isn't their reflect zero value (e.g. because of a cached field) can
+// collapse to null.
+// The method name is specific to this package, so that types with an IsZero method (like time.Time, whose IsZero
+// ignores the location) keep being checked with reflection.
+// Both value and pointer receivers are supported. It isn't consulted for pointer types, whose zero value is always nil.
+// Zeroer changes what counts as zero, not how fast it is checked: the method is called on a copy of the value that
+// escapes to the heap, which costs an allocation per Some and is slower than the reflection check it replaces.
+type Zeroer interface {
+	IsOptionalZero() bool
+}
+
+// NullChecker is implemented by types that decide whether a value is null, e.g. when their zero value is meaningful
+// (a Money with an Amount of 0) or when another value stands for null.
+// Some and MarshalJSON consult it before Zeroer and reflection. Both value and pointer receivers are supported.
+// It isn't consulted for pointer types, whose nil value is always null. Like Zeroer, it costs an allocation per Some.
+type NullChecker interface {
+	IsNullValue() bool
+}
+
+// isNullValue reports whether Some turns *v into null: if it reports itself as null (see NullChecker), or if it is the
+// zero value of its type, checked without reflection for the common types.
+// It takes a pointer so that large values aren't copied.
+func isNullValue[T any](v *T) bool {
+	switch p := any(v).(type) {
+	case *bool:
+		return !*p
+	case *string:
+		return *p == ""
+	case *int:
+		return *p == 0
+	case *int8:
+		return *p == 0
+	case *int16:
+		return *p == 0
+	case *int32:
+		return *p == 0
+	case *int64:
+		return *p == 0
+	case *uint:
+		return *p == 0
+	case *uint8:
+		return *p == 0
+	case *uint16:
+		return *p == 0
+	case *uint32:
+		return *p == 0
+	case *uint64:
+		return *p == 0
+	case *uintptr:
+		return *p == 0
+	case *float32:
+		// like reflect, -0 is also the zero value
+		return *p == 0
+	case *float64:
+		return *p == 0
+	case *time.Time:
+		// compare all the fields like reflect does, not with IsZero, which ignores the location
+		return *p == time.Time{}
+	case NullChecker:
+		// call it on a copy, so that only the types that implement NullChecker pay for it escaping to the heap: calling it
+		// through v would move the argument of every Some to the heap
+		return isNullValueChecker(*v)
+	case Zeroer:
+		// call it on a copy, so that only the types that implement Zeroer pay for it escaping to the heap
+		return isZeroZeroer(*v)
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	if rv.Kind() == reflect.Interface {
+		// a nil interface is zero, otherwise the held value decides
+		return rv.IsNil() || rv.Elem().IsZero()
+	}
+	return rv.IsZero()
+}
+
+func isZeroZeroer[T any](v T) bool {
+	return any(&v).(Zeroer).IsOptionalZero()
+}
+
+func isNullValueChecker[T any](v T) bool {
+	return any(&v).(NullChecker).IsNullValue()
+}
+
+// checksNull reports whether *v implements NullChecker and reports itself as null.
+func checksNull[T any](v *T) bool {
+	if _, ok := any(v).(NullChecker); !ok {
+		return false
+	}
+	return isNullValueChecker(*v)
+}
@@ -0,0 +1,301 @@
+package optionalv2_test
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	optionalv2 "github.com/tapp-ai/go-optional-v2"
+)
+
+// version implements optionalv2.Zeroer with its own semantics: a version without a number is zero, whatever its label.
+type version struct {
+	Number int
+	Label  string
+}
+
+func (v version) IsOptionalZero() bool {
+	return v.Number == 0
+}
+
+// decimal has an IsZero method that doesn't match its reflect zero value, like many decimal types.
+type decimal struct {
+	Coefficient int64
+	Exponent    int32
+}
+
+func (d decimal) IsZero() bool {
+	return d.Coefficient == 0
+}
+
+type largeStruct struct {
+	ID       int64
+	Name     string
+	Email    string
+	Tags     [8]string
+	Scores   [16]float64
+	Created  time.Time
+	Disabled bool
+}
+
+// largeZeroer is largeStruct with a hand-written zero check.
+type largeZeroer largeStruct
+
+func (l largeZeroer) IsOptionalZero() bool {
+	return l == largeZeroer{}
+}
+
+func TestZeroer(t *testing.T) {
+	// Test the fast paths agree with reflection
+	t.Run("MatchesReflection", func(t *testing.T) {
+		type named int
+		values := []any{
+			0, 1, int8(0), int16(-1), int32(0), int64(5), uint(0), uint8(1), uint16(0), uint32(2), uint64(0), uintptr(0),
+			float32(0), float64(1.5), math.Copysign(0, -1), math.NaN(), "", "a", false, true, named(0), named(1),
+			[]int(nil), struct{}{}, time.Time{}, time.Now(), time.Time{}.In(time.FixedZone("CET", 3600)), time.Unix(0, 0),
+		}
+		for _, v := range values {
+			want := reflect.ValueOf(v).IsZero()
+			assert.Equal(t, want, optionalv2.Some(v).State() == optionalv2.StateNull, "%T(%v)", v, v)
+		}
+	})
+
+	// Test a nil interface becomes null instead of panicking
+	t.Run("NilInterface", func(t *testing.T) {
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Some[any](nil).State())
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Some[error](nil).State())
+	})
+
+	// Test Zeroer is used for value types
+	t.Run("Zeroer", func(t *testing.T) {
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Some(version{Label: "draft"}).State())
+		assert.Equal(t, optionalv2.StateSome, optionalv2.Some(version{Number: 1}).State())
+	})
+
+	// Test IsZero methods aren't consulted, so types like time.Time and decimals keep their reflect semantics
+	t.Run("IsZeroMethod", func(t *testing.T) {
+		zeroInZone := time.Time{}.In(time.FixedZone("CET", 3600))
+		assert.True(t, zeroInZone.IsZero())
+		assert.Equal(t, optionalv2.StateSome, optionalv2.Some(zeroInZone).State())
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Some(time.Time{}).State())
+		assert.Equal(t, optionalv2.StateSome, optionalv2.Some(decimal{Exponent: 2}).State())
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Some(decimal{}).State())
+	})
+
+	// Test Zeroer isn't used for pointer types
+	t.Run("Pointer", func(t *testing.T) {
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Some[*time.Time](nil).State())
+		assert.Equal(t, optionalv2.StateSome, optionalv2.Some(&time.Time{}).State())
+		assert.Equal(t, optionalv2.StateSome, optionalv2.Some(&version{}).State())
+	})
+}
+
+// someReflect is Some as it was implemented before the fast paths, to compare against.
+func someReflect[T any](v T) optionalv2.Option[T] {
+	if reflect.ValueOf(v).IsZero() {
+		return optionalv2.Option[T]{false: v}
+	}
+	return optionalv2.Option[T]{true: v}
+}
+
+func BenchmarkSome(b *testing.B) {
+	now := time.Now()
+	large := largeStruct{ID: 1, Name: "Alice", Created: now}
+
+	b.Run("Int", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = optionalv2.Some(i)
+		}
+	})
+	b.Run("IntReflect", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = someReflect(i)
+		}
+	})
+	b.Run("String", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = optionalv2.Some("Alice")
+		}
+	})
+	b.Run("StringReflect", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = someReflect("Alice")
+		}
+	})
+	b.Run("Time", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = optionalv2.Some(now)
+		}
+	})
+	b.Run("TimeReflect", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = someReflect(now)
+		}
+	})
+	b.Run("LargeStruct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = optionalv2.Some(large)
+		}
+	})
+	b.Run("LargeStructZeroer", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = optionalv2.Some(largeZeroer(large))
+		}
+	})
+	b.Run("LargeStructReflect", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = someReflect(large)
+		}
+	})
+}
+
+// isZeroReflect is the check Some did before the fast paths, to compare against.
+func isZeroReflect[T any](v T) bool {
+	return reflect.ValueOf(v).IsZero()
+}
+
+// nullSink keeps the compiler from optimizing the checks away.
+var nullSink bool
+
+// BenchmarkIsNullValue measures the check Some does on its own, since BenchmarkSome is dominated by the allocation of
+// the map (about 200 ns and 2 allocs per Some). On a linux/amd64 runner with go test -bench -benchmem -count 3:
+//
+//	Int                  4 ns/op   0 allocs   IntReflect           8 ns/op   0 allocs
+//	String               6 ns/op   0 allocs   StringReflect       11 ns/op   0 allocs
+//	Time                 6 ns/op   0 allocs   TimeReflect         10 ns/op   0 allocs
+//	LargeStruct         26 ns/op   0 allocs   LargeStructReflect  28 ns/op   0 allocs
+//	LargeStructZeroer  240 ns/op   1 alloc (the value is copied to the heap to call IsOptionalZero, so Zeroer is
+//	                                        slower than reflection and only worth it for its semantics)
+func BenchmarkIsNullValue(b *testing.B) {
+	now := time.Now()
+	large := largeStruct{ID: 1, Name: "Alice", Created: now}
+
+	b.Run("Int", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			nullSink = optionalv2.IsNullValue(i)
+		}
+	})
+	b.Run("IntReflect", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			nullSink = isZeroReflect(i)
+		}
+	})
+	b.Run("String", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			nullSink = optionalv2.IsNullValue("Alice")
+		}
+	})
+	b.Run("StringReflect", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			nullSink = isZeroReflect("Alice")
+		}
+	})
+	b.Run("Time", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			nullSink = optionalv2.IsNullValue(now)
+		}
+	})
+	b.Run("TimeReflect", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			nullSink = isZeroReflect(now)
+		}
+	})
+	b.Run("LargeStruct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			nullSink = optionalv2.IsNullValue(large)
+		}
+	})
+	b.Run("LargeStructZeroer", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			nullSink = optionalv2.IsNullValue(largeZeroer(large))
+		}
+	})
+	b.Run("LargeStructReflect", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			nullSink = isZeroReflect(large)
+		}
+	})
+}
+
+// money is a struct whose zero Amount is meaningful, so it is only null without a currency.
+type money struct {
+	Amount   int64
+	Currency string
+}
+
+func (m money) IsNullValue() bool {
+	return m.Currency == ""
+}
+
+// cents is a named primitive where -1 stands for null, and 0 is a value.
+type cents int
+
+func (c cents) IsNullValue() bool {
+	return c == -1
+}
+
+// sentinelTime uses a pointer receiver, and treats only the Unix epoch as null.
+type sentinelTime struct {
+	time.Time
+}
+
+func (s *sentinelTime) IsNullValue() bool {
+	return s.Unix() == 0
+}
+
+// both implements Zeroer and NullChecker, which wins.
+type both struct {
+	N int
+}
+
+func (both) IsOptionalZero() bool { return true }
+func (both) IsNullValue() bool    { return false }
+
+func TestNullChecker(t *testing.T) {
+	// Test a struct with a value receiver
+	t.Run("Struct", func(t *testing.T) {
+		assert.Equal(t, optionalv2.StateSome, optionalv2.Some(money{Currency: "EUR"}).State())
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Some(money{Amount: 5}).State())
+	})
+
+	// Test a named primitive
+	t.Run("NamedPrimitive", func(t *testing.T) {
+		assert.Equal(t, optionalv2.StateSome, optionalv2.Some(cents(0)).State())
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Some(cents(-1)).State())
+	})
+
+	// Test a pointer receiver
+	t.Run("PointerReceiver", func(t *testing.T) {
+		assert.Equal(t, optionalv2.StateSome, optionalv2.Some(sentinelTime{}).State())
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Some(sentinelTime{time.Unix(0, 0)}).State())
+	})
+
+	// Test NullChecker wins over Zeroer, and isn't consulted for pointer types
+	t.Run("Precedence", func(t *testing.T) {
+		assert.Equal(t, optionalv2.StateSome, optionalv2.Some(both{}).State())
+		assert.Equal(t, optionalv2.StateSome, optionalv2.Some(&money{}).State())
+		assert.Equal(t, optionalv2.StateNull, optionalv2.Some[*money](nil).State())
+	})
+
+	// Test MarshalJSON consults NullChecker, including for Options that weren't built with Some
+	t.Run("MarshalJSON", func(t *testing.T) {
+		type payment struct {
+			Price optionalv2.Option[money] `json:"price"`
+			Tip   optionalv2.Option[cents] `json:"tip"`
+		}
+		data, err := json.Marshal(payment{
+			Price: optionalv2.Option[money]{true: {Amount: 5}},
+			Tip:   optionalv2.Some(cents(0)),
+		})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"price":null,"tip":0}`, string(data))
+
+		var p payment
+		assert.NoError(t, json.Unmarshal([]byte(`{"price":{"Amount":0,"Currency":"EUR"},"tip":-1}`), &p))
+		assert.Equal(t, optionalv2.StateSome, p.Price.State())
+		assert.Equal(t, optionalv2.StateNull, p.Tip.State())
+	})
+}